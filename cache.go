@@ -0,0 +1,497 @@
+package gitlab
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheEntry is a cached GET response
+type CacheEntry struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	ETag       string      `json:"etag,omitempty"`
+	StoredAt   time.Time   `json:"stored_at"`
+	// Resources are what a change drops the entry for: the resource the
+	// response belongs to ("/projects/42"), prefixed with "*/" the listing
+	// it is ("*/projects") and, suffixed with "/*", the collection it is
+	// under ("/projects/*")
+	Resources []string `json:"resources,omitempty"`
+}
+
+// Cache stores responses for WithCache.  Keys start with the request URL,
+// followed by a space and a digest of the credentials used, so one token
+// never sees responses fetched with another.  KeysFor returns the keys
+// whose entry lists resource in its Resources.
+type Cache interface {
+	Get(key string) (CacheEntry, bool)
+	Set(key string, entry CacheEntry)
+	Delete(key string)
+	KeysFor(resource string) []string
+}
+
+// WithCache caches successful GET responses in c.  Entries younger than ttl
+// are served without contacting GitLab, older ones are revalidated with
+// If-None-Match when GitLab sent an ETag and served from the cache on a
+// 304.  A ttl of 0 revalidates every request.  Any other request method
+// drops the cached responses of the resource it touches, e.g. a PUT to
+// /projects/42/variables/KEY invalidates everything under /projects/42 and
+// the project listings.  Changes that can show in other groups and
+// projects, updating, deleting, restoring or transferring a group or
+// project, sharing it and changing its members, drop everything cached
+// under /groups and /projects.  Responses served from the cache carry an
+// X-From-Cache header and ResponseMeta.Cached is set.  Per-request headers
+// (X-Request-Id, X-Runtime, RateLimit-*) are never cached: a fresh hit has
+// none and a revalidated response carries those of the 304.  CI/CD
+// variables, anything under a /variables path, are never cached either,
+// so their values are not kept in memory or written to a DiskCache.
+func WithCache(c Cache, ttl time.Duration) ClientOption {
+	return func(cfg *clientConfig) error {
+		if ttl < 0 {
+			return errors.New("cache ttl must not be negative")
+		}
+		cfg.cache = c
+		cfg.cacheTTL = ttl
+		return nil
+	}
+}
+
+// cachingTransport serves GET requests from a Cache
+type cachingTransport struct {
+	next    http.RoundTripper
+	cache   Cache
+	ttl     time.Duration
+	apiPath string
+	now     func() time.Time
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	if req.Method != http.MethodGet {
+		resp, err := t.next.RoundTrip(req)
+		if err == nil && req.Method != http.MethodHead && resp.StatusCode < 400 {
+			t.invalidate(req.URL)
+		}
+		return resp, err
+	}
+
+	if !cacheable(req.URL) {
+		return t.next.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	entry, cached := t.cache.Get(key)
+	if cached && t.ttl > 0 && t.now().Sub(entry.StoredAt) < t.ttl {
+		return entry.response(req), nil
+	}
+	if cached && entry.ETag != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case cached && resp.StatusCode == http.StatusNotModified:
+		resp.Body.Close()
+		entry.StoredAt = t.now()
+		entry.Header = storableHeader(entry.Header)
+		for k, v := range resp.Header {
+			switch k {
+			case "Content-Length", "Content-Type", "Content-Encoding", "Transfer-Encoding":
+				continue
+			}
+			if !isPerRequestHeader(k) {
+				entry.Header[k] = v
+			}
+		}
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			entry.ETag = etag
+		}
+		entry.Resources = t.resources(req.URL)
+		t.cache.Set(key, entry)
+		revalidated := entry.response(req)
+		for k, v := range resp.Header {
+			if isPerRequestHeader(k) {
+				revalidated.Header[k] = v
+			}
+		}
+		return revalidated, nil
+
+	case resp.StatusCode == http.StatusOK:
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if resp.Header.Get("ETag") != "" || t.ttl > 0 {
+			t.cache.Set(key, CacheEntry{
+				StatusCode: resp.StatusCode,
+				Header:     storableHeader(resp.Header),
+				Body:       body,
+				ETag:       resp.Header.Get("ETag"),
+				StoredAt:   t.now(),
+				Resources:  t.resources(req.URL),
+			})
+		}
+	}
+	return resp, nil
+}
+
+// cacheListings are the listings that may include a resource of each
+// collection, dropped along with the resource itself
+var cacheListings = map[string][]string{
+	"projects": {"projects"},
+	"groups":   {"groups", "subgroups", "descendant_groups", "all"},
+	"users":    {"users", "members", "all"},
+}
+
+// namespaceChanges are the sub-resources of a group or project whose change
+// can show in any other group or project: full paths, shares and inherited
+// members
+var namespaceChanges = map[string]bool{
+	"transfer": true,
+	"restore":  true,
+	"share":    true,
+	"members":  true,
+}
+
+// cacheable reports whether the response to a GET of u may be cached, CI/CD
+// variables hold secrets and never are
+func cacheable(u *url.URL) bool {
+	for _, segment := range strings.Split(u.EscapedPath(), "/") {
+		if segment == "variables" {
+			return false
+		}
+	}
+	return true
+}
+
+// resources are the CacheEntry.Resources of a response to a GET of u
+func (t *cachingTransport) resources(u *url.URL) []string {
+	segments := apiSegments(u, t.apiPath)
+	return []string{resourceRoot(segments), "*/" + segments[len(segments)-1], "/" + segments[0] + "/*"}
+}
+
+// invalidate drops the cached responses of the resource u belongs to, e.g.
+// /projects/42 for /projects/42/variables/KEY, and the listings that may
+// include it.  A namespace change drops every group and project.
+func (t *cachingTransport) invalidate(u *url.URL) {
+
+	segments := apiSegments(u, t.apiPath)
+	dropped := t.cache.KeysFor(resourceRoot(segments))
+	for _, listing := range cacheListings[segments[0]] {
+		dropped = append(dropped, t.cache.KeysFor("*/"+listing)...)
+	}
+	if namespaceChange(segments) {
+		dropped = append(dropped, t.cache.KeysFor("/groups/*")...)
+		dropped = append(dropped, t.cache.KeysFor("/projects/*")...)
+	}
+	for _, key := range dropped {
+		t.cache.Delete(key)
+	}
+}
+
+// namespaceChange reports whether a change to the API relative path
+// segments can show in other groups and projects, see namespaceChanges
+func namespaceChange(segments []string) bool {
+	if segments[0] != "groups" && segments[0] != "projects" {
+		return false
+	}
+	return len(segments) == 2 || len(segments) > 2 && namespaceChanges[segments[2]]
+}
+
+// apiSegments splits the path of u relative to apiPath
+func apiSegments(u *url.URL, apiPath string) []string {
+	return strings.Split(strings.Trim(apiRelativePath(u.EscapedPath(), apiPath), "/"), "/")
+}
+
+// resourceRoot is the resource a path belongs to, its first two segments
+func resourceRoot(segments []string) string {
+	if len(segments) > 1 {
+		return "/" + segments[0] + "/" + segments[1]
+	}
+	return "/" + segments[0]
+}
+
+// isPerRequestHeader reports whether the header k describes one particular
+// response rather than the resource, and so must not be replayed
+func isPerRequestHeader(k string) bool {
+	k = http.CanonicalHeaderKey(k)
+	return k == "X-Request-Id" || k == "X-Runtime" || strings.HasPrefix(k, "Ratelimit-") || k == "Retry-After"
+}
+
+// storableHeader is a copy of header without the per-request headers
+func storableHeader(header http.Header) http.Header {
+	stored := http.Header{}
+	for k, v := range header {
+		if !isPerRequestHeader(k) {
+			stored[k] = append([]string(nil), v...)
+		}
+	}
+	return stored
+}
+
+// cacheKey is the request URL followed by a digest of its credentials
+func cacheKey(req *http.Request) string {
+	return req.URL.String() + " " + credentialDigest(req.Header)
+}
+
+// credentialDigest identifies the credentials in header without revealing
+// them
+func credentialDigest(header http.Header) string {
+	h := sha256.New()
+	for _, secret := range headerSecrets(header) {
+		h.Write([]byte(secret))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func (e CacheEntry) response(req *http.Request) *http.Response {
+	header := storableHeader(e.Header)
+	header.Set("X-From-Cache", "1")
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// MemoryCache is an in-memory least recently used Cache
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List
+	items      map[string]*list.Element
+	resources  map[string]map[string]bool
+}
+
+type memoryCacheItem struct {
+	key   string
+	entry CacheEntry
+}
+
+// NewMemoryCache holds up to maxEntries responses, evicting the least
+// recently used.  maxEntries <= 0 means no limit.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		items:      map[string]*list.Element{},
+		resources:  map[string]map[string]bool{},
+	}
+}
+
+func (c *MemoryCache) Get(key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*memoryCacheItem).entry, true
+}
+
+func (c *MemoryCache) Set(key string, entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		item := el.Value.(*memoryCacheItem)
+		c.unindex(key, item.entry.Resources)
+		item.entry = entry
+		c.index(key, entry.Resources)
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.order.PushFront(&memoryCacheItem{key: key, entry: entry})
+	c.index(key, entry.Resources)
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+}
+
+func (c *MemoryCache) KeysFor(resource string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]string, 0, len(c.resources[resource]))
+	for key := range c.resources[resource] {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (c *MemoryCache) remove(el *list.Element) {
+	item := el.Value.(*memoryCacheItem)
+	c.order.Remove(el)
+	delete(c.items, item.key)
+	c.unindex(item.key, item.entry.Resources)
+}
+
+func (c *MemoryCache) index(key string, resources []string) {
+	for _, resource := range resources {
+		if c.resources[resource] == nil {
+			c.resources[resource] = map[string]bool{}
+		}
+		c.resources[resource][key] = true
+	}
+}
+
+func (c *MemoryCache) unindex(key string, resources []string) {
+	for _, resource := range resources {
+		delete(c.resources[resource], key)
+		if len(c.resources[resource]) == 0 {
+			delete(c.resources, resource)
+		}
+	}
+}
+
+// DiskCache is a Cache storing one JSON file per response in a directory.
+// Each resource has a directory under index/ with a file per key cached for
+// it, so KeysFor only reads the keys of that resource.
+type DiskCache struct {
+	mu  sync.Mutex
+	dir string
+}
+
+type diskCacheFile struct {
+	Key   string     `json:"key"`
+	Entry CacheEntry `json:"entry"`
+}
+
+// NewDiskCache stores responses in dir, creating it if needed
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("creating cache directory %s: %w", dir, err)
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// name is the file name of key, a digest as keys hold URLs
+func (c *DiskCache) name(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *DiskCache) file(key string) string {
+	return filepath.Join(c.dir, c.name(key)+".json")
+}
+
+func (c *DiskCache) indexDir(resource string) string {
+	return filepath.Join(c.dir, "index", c.name(resource))
+}
+
+func (c *DiskCache) read(path string) (diskCacheFile, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return diskCacheFile{}, false
+	}
+	var f diskCacheFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return diskCacheFile{}, false
+	}
+	return f, true
+}
+
+func (c *DiskCache) Get(key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f, ok := c.read(c.file(key))
+	if !ok || f.Key != key {
+		return CacheEntry{}, false
+	}
+	return f.Entry, true
+}
+
+// Set writes the entry, a failed write only means a later cache miss.  The
+// index is written first, so an entry on disk is always found by KeysFor.
+func (c *DiskCache) Set(key string, entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, err := json.Marshal(diskCacheFile{Key: key, Entry: entry})
+	if err != nil {
+		return
+	}
+	for _, resource := range entry.Resources {
+		dir := c.indexDir(resource)
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return
+		}
+		if err := os.WriteFile(filepath.Join(dir, c.name(key)), []byte(key), 0600); err != nil {
+			return
+		}
+	}
+	path := c.file(key)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+	}
+}
+
+func (c *DiskCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	path := c.file(key)
+	if f, ok := c.read(path); ok {
+		for _, resource := range f.Entry.Resources {
+			os.Remove(filepath.Join(c.indexDir(resource), c.name(key)))
+		}
+	}
+	os.Remove(path)
+}
+
+// KeysFor reads the index of resource, dropping the keys whose entry is
+// gone
+func (c *DiskCache) KeysFor(resource string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	dir := c.indexDir(resource)
+	files, _ := os.ReadDir(dir)
+	keys := make([]string, 0, len(files))
+	for _, file := range files {
+		path := filepath.Join(dir, file.Name())
+		if _, err := os.Stat(filepath.Join(c.dir, file.Name()+".json")); err != nil {
+			os.Remove(path)
+			continue
+		}
+		if key, err := os.ReadFile(path); err == nil {
+			keys = append(keys, string(key))
+		}
+	}
+	return keys
+}
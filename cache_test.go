@@ -0,0 +1,273 @@
+package gitlab_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	gitlab "github.com/maahsome/gitlab-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cachedGet reports whether get was answered from the cache
+func cachedGet(t *testing.T, get func(ctx context.Context) error) bool {
+	t.Helper()
+	ctx, rec := gitlab.RecordResponseMeta(context.Background())
+	require.NoError(t, get(ctx))
+	meta, ok := rec.Last()
+	require.True(t, ok)
+	return meta.Cached
+}
+
+func TestDiskCacheNeverStoresVariables(t *testing.T) {
+
+	dir := t.TempDir()
+	cache, err := gitlab.NewDiskCache(dir)
+	require.NoError(t, err)
+	fake, client := newFakeClient(t, gitlab.WithCache(cache, time.Hour))
+	g := fake.AddGroup(gitlab.Group{Path: "platform", Name: "Platform"})
+	project := fake.AddProject(g.ID, gitlab.Project{Path: "site", Name: "site"})
+	fake.SetVariable("projects", project.ID, gitlab.Variable{Key: "DEPLOY_TOKEN", Value: "s3cr3t-value"})
+
+	getVariables := func(ctx context.Context) error {
+		_, err := client.GetWithContext(ctx, fmt.Sprintf("/projects/%d/variables", project.ID))
+		return err
+	}
+	assert.False(t, cachedGet(t, getVariables))
+	assert.False(t, cachedGet(t, getVariables))
+	_, err = client.GetCicdVariables(project.ID)
+	require.NoError(t, err)
+
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		assert.NotContains(t, string(data), "s3cr3t-value", path)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestDiskCacheInvalidation(t *testing.T) {
+
+	dir := t.TempDir()
+	cache, err := gitlab.NewDiskCache(dir)
+	require.NoError(t, err)
+	fake, client := newFakeClient(t, gitlab.WithCache(cache, time.Hour))
+	g := fake.AddGroup(gitlab.Group{Path: "platform", Name: "Platform"})
+	project := fake.AddProject(g.ID, gitlab.Project{Path: "site", Name: "site"})
+	fake.SetVariable("projects", project.ID, gitlab.Variable{Key: "DEPLOY_TOKEN", Value: "initial"})
+
+	getProject := func(ctx context.Context) error {
+		_, err := client.GetProjectWithContext(ctx, project.ID)
+		return err
+	}
+	getGroup := func(ctx context.Context) error {
+		_, err := client.GetGroupWithContext(ctx, g.ID)
+		return err
+	}
+	getGroupProjects := func(ctx context.Context) error {
+		_, err := client.GetGroupProjectsWithContext(ctx, g.ID)
+		return err
+	}
+	for _, get := range []func(context.Context) error{getProject, getGroup, getGroupProjects} {
+		assert.False(t, cachedGet(t, get))
+		assert.True(t, cachedGet(t, get))
+	}
+
+	// a second client on the same directory shares the entries and the index
+	reopened, err := gitlab.NewDiskCache(dir)
+	require.NoError(t, err)
+	other, err := fake.Client(gitlab.WithLogger(gitlab.NopLogger()), gitlab.WithCache(reopened, time.Hour))
+	require.NoError(t, err)
+	_, err = other.UpdateVariableFrom(project.ID, "projects", "DEPLOY_TOKEN", "rotated")
+	require.NoError(t, err)
+
+	assert.False(t, cachedGet(t, getProject), "the changed project is refetched")
+	assert.False(t, cachedGet(t, getGroupProjects), "project listings are refetched")
+	assert.True(t, cachedGet(t, getGroup), "other resources stay cached")
+}
+
+func TestDiskCacheIndex(t *testing.T) {
+
+	cache, err := gitlab.NewDiskCache(t.TempDir())
+	require.NoError(t, err)
+	cache.Set("a", gitlab.CacheEntry{StatusCode: 200, Resources: []string{"/projects/1", "*/projects"}})
+	cache.Set("b", gitlab.CacheEntry{StatusCode: 200, Resources: []string{"/projects/2"}})
+
+	assert.Equal(t, []string{"a"}, cache.KeysFor("/projects/1"))
+	assert.Equal(t, []string{"a"}, cache.KeysFor("*/projects"))
+	assert.Empty(t, cache.KeysFor("/projects/3"))
+
+	cache.Delete("a")
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+	assert.Empty(t, cache.KeysFor("/projects/1"))
+	assert.Empty(t, cache.KeysFor("*/projects"))
+	assert.Equal(t, []string{"b"}, cache.KeysFor("/projects/2"))
+}
+
+func TestMemoryCacheEviction(t *testing.T) {
+
+	cache := gitlab.NewMemoryCache(2)
+	cache.Set("a", gitlab.CacheEntry{StatusCode: 200, Resources: []string{"/groups/1"}})
+	cache.Set("b", gitlab.CacheEntry{StatusCode: 200, Resources: []string{"/groups/1"}})
+	_, ok := cache.Get("a")
+	require.True(t, ok)
+
+	// b is now the least recently used
+	cache.Set("c", gitlab.CacheEntry{StatusCode: 200, Resources: []string{"/groups/2"}})
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	_, ok = cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a"}, cache.KeysFor("/groups/1"))
+
+	cache.Set("d", gitlab.CacheEntry{StatusCode: 200})
+	keys := append(cache.KeysFor("/groups/1"), cache.KeysFor("/groups/2")...)
+	sort.Strings(keys)
+	assert.Equal(t, []string{"a"}, keys, "c was evicted")
+}
+
+func TestCacheRequestIDs(t *testing.T) {
+
+	for _, ttl := range []time.Duration{time.Hour, 0} {
+		t.Run(ttl.String(), func(t *testing.T) {
+			fake, client := newFakeClient(t, gitlab.WithCache(gitlab.NewMemoryCache(10), ttl))
+			g := fake.AddGroup(gitlab.Group{Path: "platform", Name: "Platform"})
+
+			var metas []gitlab.ResponseMeta
+			for i := 0; i < 2; i++ {
+				ctx, rec := gitlab.RecordResponseMeta(context.Background())
+				_, err := client.GetGroupWithContext(ctx, g.ID)
+				require.NoError(t, err)
+				meta, _ := rec.Last()
+				metas = append(metas, meta)
+			}
+			assert.False(t, metas[0].Cached)
+			assert.True(t, metas[1].Cached)
+			assert.NotEqual(t, metas[0].RequestId, metas[1].RequestId, "a cached response must not reuse an earlier request id")
+			if ttl == 0 {
+				// revalidated with a 304, which has its own request id
+				assert.NotEmpty(t, metas[1].RequestId)
+			}
+		})
+	}
+}
+
+// namespaceFixture is a project in platform/web and a security group
+type namespaceFixture struct {
+	client              gitlab.GitlabClient
+	root, sub, security gitlab.Group
+	project             gitlab.Project
+	alice               int
+}
+
+func TestCacheNamespaceInvalidation(t *testing.T) {
+
+	for name, tc := range map[string]struct {
+		change func(f namespaceFixture) error
+		// gone reports whether the project's old full path stops resolving
+		gone bool
+	}{
+		"transfer group": {
+			change: func(f namespaceFixture) error {
+				_, err := f.client.Groups().TransferGroup(f.sub.ID, f.security.ID)
+				return err
+			},
+			gone: true,
+		},
+		"rename group": {
+			change: func(f namespaceFixture) error {
+				_, err := f.client.Groups().UpdateGroup(f.root.ID, gitlab.UpdateGroupOptions{Path: gitlab.String("plat")})
+				return err
+			},
+			gone: true,
+		},
+		"delete group": {
+			change: func(f namespaceFixture) error {
+				_, err := f.client.Groups().DeleteGroup(f.root.ID, nil)
+				return err
+			},
+			gone: true,
+		},
+		"share group": {
+			change: func(f namespaceFixture) error {
+				_, err := f.client.Groups().ShareGroup(f.sub.ID, f.security.ID, gitlab.ReporterAccess, nil)
+				return err
+			},
+		},
+		"share project": {
+			change: func(f namespaceFixture) error {
+				_, err := f.client.Projects().ShareProject(f.project.ID, f.security.ID, gitlab.ReporterAccess, nil)
+				return err
+			},
+		},
+		"add member to parent": {
+			change: func(f namespaceFixture) error {
+				_, err := f.client.Members().AddGroupMemberOpts(f.root.ID, f.alice, gitlab.MemberOptions{AccessLevel: gitlab.DeveloperAccess})
+				return err
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fake, client := newFakeClient(t, gitlab.WithCache(gitlab.NewMemoryCache(100), time.Hour))
+			alice := fake.AddUser("alice", "Alice")
+			root := fake.AddGroup(gitlab.Group{Path: "platform", Name: "Platform"})
+			sub := fake.AddGroup(gitlab.Group{Path: "web", Name: "Web", ParentID: root.ID})
+			security := fake.AddGroup(gitlab.Group{Path: "security", Name: "Security"})
+			project := fake.AddProject(sub.ID, gitlab.Project{Path: "site", Name: "site"})
+
+			gets := map[string]func(ctx context.Context) error{
+				"project": func(ctx context.Context) error {
+					_, err := client.GetProjectWithContext(ctx, project.ID)
+					return err
+				},
+				"other group": func(ctx context.Context) error {
+					_, err := client.GetGroupWithContext(ctx, security.ID)
+					return err
+				},
+				"inherited members": func(ctx context.Context) error {
+					_, err := client.Members().ListAllProjectMembersWithContext(ctx, project.ID, nil)
+					return err
+				},
+			}
+			byPath := func(ctx context.Context) error {
+				_, err := client.GetProjectIDWithContext(ctx, "platform/web/site")
+				return err
+			}
+			for _, get := range gets {
+				assert.False(t, cachedGet(t, get))
+				assert.True(t, cachedGet(t, get))
+			}
+			assert.False(t, cachedGet(t, byPath))
+			assert.True(t, cachedGet(t, byPath))
+
+			require.NoError(t, tc.change(namespaceFixture{client, root, sub, security, project, alice}))
+
+			// refetched, whether or not the resource still exists
+			for what, get := range gets {
+				ctx, rec := gitlab.RecordResponseMeta(context.Background())
+				_ = get(ctx)
+				meta, ok := rec.Last()
+				require.True(t, ok, what)
+				assert.False(t, meta.Cached, what)
+			}
+			if tc.gone {
+				_, err := client.GetProjectID("platform/web/site")
+				assert.True(t, gitlab.IsNotFound(err), "the old path must not resolve from the cache, got %v", err)
+			} else {
+				assert.False(t, cachedGet(t, byPath), "path lookups")
+			}
+		})
+	}
+}
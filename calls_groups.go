@@ -1,37 +1,33 @@
 package gitlab
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"strings"
-
-	"github.com/sirupsen/logrus"
+	"net/http"
 )
 
-// GetGroupID - returns the group ID based on the namespace/group path (slug)
+// GetGroupID - returns the group ID based on the namespace/group path (slug),
+// e.g. "group/sub", which is escaped by the client.  Pass the path
+// unescaped: an already escaped "group%2Fsub" is escaped again and not
+// found.
 //
 // GitLab API docs:
 // https://docs.gitlab.com/ee/api/groups.html#details-of-a-group
 func (r *gitlabClient) GetGroupID(groupPath string) (int, error) {
+	return r.GetGroupIDWithContext(context.Background(), groupPath)
+}
 
-	uri := fmt.Sprintf("/groups/%s", groupPath)
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	// fmt.Printf("fetchUri: %s\n", fetchUri)
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		Get(fetchUri)
+// GetGroupIDWithContext - GetGroupID bound to ctx
+func (r *gitlabClient) GetGroupIDWithContext(ctx context.Context, groupPath string) (int, error) {
 
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
-		return 0, resperr
+	gid, err := pathID(groupPath)
+	if err != nil {
+		return 0, err
 	}
-
+	uri := fmt.Sprintf("/groups/%s", gid)
 	var pi ProjectInfo
-	marshErr := json.Unmarshal(resp.Body(), &pi)
-	if marshErr != nil {
-		logrus.Fatal("Cannot marshall Pipeline", marshErr)
-		return 0, resperr
+	if _, err := r.do(ctx, http.MethodGet, uri, nil, &pi); err != nil {
+		return 0, err
 	}
 
 	return pi.ID, nil
@@ -42,169 +38,367 @@ func (r *gitlabClient) GetGroupID(groupPath string) (int, error) {
 //
 // GitLab API docs:
 func (r *gitlabClient) GetGroup(groupID int) (Group, error) {
+	return r.GetGroupWithContext(context.Background(), groupID)
+}
+
+// GetGroupWithContext - GetGroup bound to ctx
+func (r *gitlabClient) GetGroupWithContext(ctx context.Context, groupID int) (Group, error) {
 
 	uri := fmt.Sprintf("/groups/%d", groupID)
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	// fmt.Printf("fetchUri: %s\n", fetchUri)
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		Get(fetchUri)
+	var gr Group
+	if _, err := r.do(ctx, http.MethodGet, uri, nil, &gr); err != nil {
+		return Group{}, err
+	}
 
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
-		return Group{}, resperr
+	return gr, nil
+
+}
+
+// CreateGroup - creates a group, or a subgroup when opts.ParentID is set
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/groups.html#new-group
+func (r *gitlabClient) CreateGroup(opts CreateGroupOptions) (Group, error) {
+	return r.CreateGroupWithContext(context.Background(), opts)
+}
+
+// CreateGroupWithContext - CreateGroup bound to ctx
+func (r *gitlabClient) CreateGroupWithContext(ctx context.Context, opts CreateGroupOptions) (Group, error) {
+
+	var gr Group
+	if _, err := r.do(ctx, http.MethodPost, "/groups", opts, &gr); err != nil {
+		return Group{}, err
 	}
 
+	r.logger.Info("group created", "id", gr.ID, "path", gr.FullPath)
+
+	return gr, nil
+
+}
+
+// UpdateGroup - changes the settings of groupID set in opts
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/groups.html#update-group
+func (r *gitlabClient) UpdateGroup(groupID int, opts UpdateGroupOptions) (Group, error) {
+	return r.UpdateGroupWithContext(context.Background(), groupID, opts)
+}
+
+// UpdateGroupWithContext - UpdateGroup bound to ctx
+func (r *gitlabClient) UpdateGroupWithContext(ctx context.Context, groupID int, opts UpdateGroupOptions) (Group, error) {
+
+	uri := fmt.Sprintf("/groups/%d", groupID)
 	var gr Group
-	marshErr := json.Unmarshal(resp.Body(), &gr)
-	if marshErr != nil {
-		logrus.Fatal("Cannot marshall Pipeline", marshErr)
-		return Group{}, resperr
+	if _, err := r.do(ctx, http.MethodPut, uri, opts, &gr); err != nil {
+		return Group{}, err
 	}
 
 	return gr, nil
 
 }
 
-// GetGroups- returns a list of groups
+// DeleteGroup - deletes groupID with its subgroups and projects.  Where
+// delayed deletion is enabled GitLab only marks the group for deletion,
+// the returned GroupDeletion says so and when.  opts may be nil.
 //
 // GitLab API docs:
-// https://docs.gitlab.com/ee/api/projects.html#get-single-project
-func (r *gitlabClient) GetGroups(search string) (GroupList, error) {
+// https://docs.gitlab.com/ee/api/groups.html#remove-group
+func (r *gitlabClient) DeleteGroup(groupID int, opts *DeleteGroupOptions) (GroupDeletion, error) {
+	return r.DeleteGroupWithContext(context.Background(), groupID, opts)
+}
 
-	nextPage := "1"
-	combinedResults := ""
-	uri := "/groups?per_page=100&all_available=true"
-	for {
-		fetchUri := fmt.Sprintf("https://%s%s%s&page=%s", r.BaseUrl, r.ApiPath, uri, nextPage)
-		// fmt.Printf("fetchUri: %s\n", fetchUri)
-		resp, resperr := r.Client.R().
-			SetHeader("PRIVATE-TOKEN", r.Token).
-			SetHeader("Content-Type", "application/json").
-			Get(fetchUri)
-
-		if resperr != nil {
-			logrus.WithError(resperr).Error("Oops")
-			return GroupList{}, resperr
-		}
-		items := strings.TrimPrefix(string(resp.Body()[:]), "[")
-		items = strings.TrimSuffix(items, "]")
-		if combinedResults == "" {
-			combinedResults += items
-		} else {
-			combinedResults += fmt.Sprintf(", %s", items)
-		}
-		currentPage := resp.Header().Get("X-Page")
-		nextPage = resp.Header().Get("X-Next-Page")
-		totalPages := resp.Header().Get("X-Total-Pages")
-		if currentPage == totalPages {
-			break
+// DeleteGroupWithContext - DeleteGroup bound to ctx
+func (r *gitlabClient) DeleteGroupWithContext(ctx context.Context, groupID int, opts *DeleteGroupOptions) (GroupDeletion, error) {
+
+	if opts == nil {
+		opts = &DeleteGroupOptions{}
+	}
+	uri, err := withQuery(fmt.Sprintf("/groups/%d", groupID), opts)
+	if err != nil {
+		return GroupDeletion{}, err
+	}
+	resp, err := r.do(ctx, http.MethodDelete, uri, nil, nil)
+	if err != nil {
+		return GroupDeletion{}, err
+	}
+	if r.dryRun != nil {
+		return GroupDeletion{DryRun: true}, nil
+	}
+	if opts.PermanentlyRemove || resp.StatusCode() != http.StatusAccepted {
+		return GroupDeletion{}, nil
+	}
+
+	// GitLab answers 202 either way, a group still there and marked for
+	// deletion tells delayed deletion apart from removal in the background
+	var marked struct {
+		MarkedForDeletionOn *ISODate `json:"marked_for_deletion_on"`
+	}
+	if _, err := r.do(ctx, http.MethodGet, fmt.Sprintf("/groups/%d", groupID), nil, &marked); err != nil {
+		if IsNotFound(err) {
+			return GroupDeletion{}, nil
 		}
+		return GroupDeletion{}, err
 	}
-	surroundArray := fmt.Sprintf("[%s]", combinedResults)
-	var gl GroupList
-	marshErr := json.Unmarshal([]byte(surroundArray), &gl)
-	if marshErr != nil {
-		logrus.Fatal("Cannot marshall Pipeline", marshErr)
-		return GroupList{}, marshErr
+	if marked.MarkedForDeletionOn == nil {
+		return GroupDeletion{}, nil
 	}
 
-	return gl, nil
+	r.logger.Info("group marked for deletion", "id", groupID, "on", marked.MarkedForDeletionOn.String())
+
+	return GroupDeletion{Scheduled: true, MarkedForDeletionOn: marked.MarkedForDeletionOn}, nil
 
 }
 
-// GetSubGroups- returns a list of subgroups for a given group id
+// RestoreGroup - cancels the delayed deletion of groupID
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/groups.html#restore-group-marked-for-deletion
+func (r *gitlabClient) RestoreGroup(groupID int) (Group, error) {
+	return r.RestoreGroupWithContext(context.Background(), groupID)
+}
+
+// RestoreGroupWithContext - RestoreGroup bound to ctx
+func (r *gitlabClient) RestoreGroupWithContext(ctx context.Context, groupID int) (Group, error) {
+
+	uri := fmt.Sprintf("/groups/%d/restore", groupID)
+	var gr Group
+	if _, err := r.do(ctx, http.MethodPost, uri, nil, &gr); err != nil {
+		return Group{}, err
+	}
+
+	return gr, nil
+
+}
+
+// TransferGroup - moves groupID under parentID, or to the top level when
+// parentID is 0
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/groups.html#transfer-a-group-to-a-new-parent-group--turn-a-subgroup-to-a-top-level-group
+func (r *gitlabClient) TransferGroup(groupID int, parentID int) (Group, error) {
+	return r.TransferGroupWithContext(context.Background(), groupID, parentID)
+}
+
+// TransferGroupWithContext - TransferGroup bound to ctx
+func (r *gitlabClient) TransferGroupWithContext(ctx context.Context, groupID int, parentID int) (Group, error) {
+
+	uri := fmt.Sprintf("/groups/%d/transfer", groupID)
+	body := transferGroupRequest{GroupID: parentID}
+	var gr Group
+	if _, err := r.do(ctx, http.MethodPost, uri, body, &gr); err != nil {
+		return Group{}, err
+	}
+
+	r.logger.Info("group transferred", "id", gr.ID, "path", gr.FullPath)
+
+	return gr, nil
+
+}
+
+// ShareGroup - gives the members of sharedWithGroupID access to groupID, at
+// most accessLevel, until expiresAt when it is not nil
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/groups.html#share-groups-with-groups
+func (r *gitlabClient) ShareGroup(groupID int, sharedWithGroupID int, accessLevel AccessLevel, expiresAt *ISODate) (Group, error) {
+	return r.ShareGroupWithContext(context.Background(), groupID, sharedWithGroupID, accessLevel, expiresAt)
+}
+
+// ShareGroupWithContext - ShareGroup bound to ctx
+func (r *gitlabClient) ShareGroupWithContext(ctx context.Context, groupID int, sharedWithGroupID int, accessLevel AccessLevel, expiresAt *ISODate) (Group, error) {
+
+	uri := fmt.Sprintf("/groups/%d/share", groupID)
+	body := shareRequest{
+		GroupID:     sharedWithGroupID,
+		GroupAccess: accessLevel,
+		ExpiresAt:   expiresAt,
+	}
+	var gr Group
+	if _, err := r.do(ctx, http.MethodPost, uri, body, &gr); err != nil {
+		return Group{}, err
+	}
+
+	return gr, nil
+
+}
+
+// UnshareGroup - stops sharing groupID with sharedWithGroupID
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/groups.html#delete-link-sharing-group-with-another-group
+func (r *gitlabClient) UnshareGroup(groupID int, sharedWithGroupID int) error {
+	return r.UnshareGroupWithContext(context.Background(), groupID, sharedWithGroupID)
+}
+
+// UnshareGroupWithContext - UnshareGroup bound to ctx
+func (r *gitlabClient) UnshareGroupWithContext(ctx context.Context, groupID int, sharedWithGroupID int) error {
+
+	uri := fmt.Sprintf("/groups/%d/share/%d", groupID, sharedWithGroupID)
+	_, resperr := r.do(ctx, http.MethodDelete, uri, nil, nil)
+	return resperr
+
+}
+
+// ListGroupsOptions filters ListGroups, ListSubGroups and
+// ListDescendantGroups
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/groups.html#list-groups
+type ListGroupsOptions struct {
+	PaginationOptions
+	SortOptions
+	Search         string `url:"search,omitempty"`
+	AllAvailable   *bool  `url:"all_available,omitempty"`
+	Owned          *bool  `url:"owned,omitempty"`
+	MinAccessLevel int    `url:"min_access_level,omitempty"`
+	TopLevelOnly   *bool  `url:"top_level_only,omitempty"`
+	SkipGroups     []int  `url:"skip_groups,omitempty,brackets"`
+	Statistics     *bool  `url:"statistics,omitempty"`
+}
+
+// ListGroups - returns the groups visible to the token, filtered by opts
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/groups.html#list-groups
+func (r *gitlabClient) ListGroups(opts *ListGroupsOptions) (GroupList, error) {
+	return r.ListGroupsWithContext(context.Background(), opts)
+}
+
+// ListGroupsWithContext - ListGroups bound to ctx
+func (r *gitlabClient) ListGroupsWithContext(ctx context.Context, opts *ListGroupsOptions) (GroupList, error) {
+	return r.listGroups(ctx, "/groups", opts)
+}
+
+// ListSubGroups - returns the direct subgroups of groupID, filtered by opts
 //
 // GitLab API docs:
 // https://docs.gitlab.com/ee/api/groups.html#list-a-groups-subgroups
-func (r *gitlabClient) GetSubGroups(groupID int) (GroupList, error) {
+func (r *gitlabClient) ListSubGroups(groupID int, opts *ListGroupsOptions) (GroupList, error) {
+	return r.ListSubGroupsWithContext(context.Background(), groupID, opts)
+}
 
-	nextPage := "1"
-	combinedResults := ""
-	uri := fmt.Sprintf("/groups/%d/subgroups", groupID)
-	for {
-		fetchUri := fmt.Sprintf("https://%s%s%s?page=%s", r.BaseUrl, r.ApiPath, uri, nextPage)
-		// fmt.Printf("fetchUri: %s\n", fetchUri)
-		resp, resperr := r.Client.R().
-			SetHeader("PRIVATE-TOKEN", r.Token).
-			SetHeader("Content-Type", "application/json").
-			Get(fetchUri)
-
-		if resperr != nil {
-			logrus.WithError(resperr).Error("Oops")
-			return GroupList{}, resperr
-		}
-		items := strings.TrimPrefix(string(resp.Body()[:]), "[")
-		items = strings.TrimSuffix(items, "]")
-		if combinedResults == "" {
-			combinedResults += items
-		} else {
-			combinedResults += fmt.Sprintf(", %s", items)
-		}
-		currentPage := resp.Header().Get("X-Page")
-		nextPage = resp.Header().Get("X-Next-Page")
-		totalPages := resp.Header().Get("X-Total-Pages")
-		if currentPage == totalPages {
-			break
-		}
+// ListSubGroupsWithContext - ListSubGroups bound to ctx
+func (r *gitlabClient) ListSubGroupsWithContext(ctx context.Context, groupID int, opts *ListGroupsOptions) (GroupList, error) {
+	return r.listGroups(ctx, fmt.Sprintf("/groups/%d/subgroups", groupID), opts)
+}
+
+// ListDescendantGroups - returns every group below groupID, filtered by opts
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/groups.html#list-a-groups-descendant-groups
+func (r *gitlabClient) ListDescendantGroups(groupID int, opts *ListGroupsOptions) (GroupList, error) {
+	return r.ListDescendantGroupsWithContext(context.Background(), groupID, opts)
+}
+
+// ListDescendantGroupsWithContext - ListDescendantGroups bound to ctx
+func (r *gitlabClient) ListDescendantGroupsWithContext(ctx context.Context, groupID int, opts *ListGroupsOptions) (GroupList, error) {
+	return r.listGroups(ctx, fmt.Sprintf("/groups/%d/descendant_groups", groupID), opts)
+}
+
+func (r *gitlabClient) listGroups(ctx context.Context, path string, opts *ListGroupsOptions) (GroupList, error) {
+
+	if opts == nil {
+		opts = &ListGroupsOptions{}
+	}
+	uri, err := withQuery(path, opts)
+	if err != nil {
+		return GroupList{}, err
 	}
-	surroundArray := fmt.Sprintf("[%s]", combinedResults)
-	var gl GroupList
-	marshErr := json.Unmarshal([]byte(surroundArray), &gl)
-	if marshErr != nil {
-		logrus.Fatal("Cannot marshall Pipeline", marshErr)
-		return GroupList{}, marshErr
+	gl := GroupList{}
+	if err := r.listAll(ctx, uri, opts.pager(), &gl); err != nil {
+		return GroupList{}, err
 	}
 
 	return gl, nil
 
 }
 
+// GetGroups- returns a list of groups
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/projects.html#get-single-project
+func (r *gitlabClient) GetGroups(search string) (GroupList, error) {
+	return r.GetGroupsWithContext(context.Background(), search)
+}
+
+// GetGroupsWithContext - GetGroups bound to ctx
+func (r *gitlabClient) GetGroupsWithContext(ctx context.Context, search string) (GroupList, error) {
+	return r.ListGroupsWithContext(ctx, &ListGroupsOptions{
+		PaginationOptions: PaginationOptions{PerPage: 100},
+		Search:            search,
+		AllAvailable:      Bool(true),
+	})
+}
+
+// GetSubGroups- returns a list of subgroups for a given group id
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/groups.html#list-a-groups-subgroups
+func (r *gitlabClient) GetSubGroups(groupID int) (GroupList, error) {
+	return r.GetSubGroupsWithContext(context.Background(), groupID)
+}
+
+// GetSubGroupsWithContext - GetSubGroups bound to ctx
+func (r *gitlabClient) GetSubGroupsWithContext(ctx context.Context, groupID int) (GroupList, error) {
+	return r.ListSubGroupsWithContext(ctx, groupID, nil)
+}
+
 // GetDescendantGroups - returns a list of descendant_groups for a groupID
 //
 // GitLab API docs:
 // https://docs.gitlab.com/ee/api/groups.html#list-a-groups-descendant-groups
 func (r *gitlabClient) GetDescendantGroups(groupID int) (GroupList, error) {
+	return r.GetDescendantGroupsWithContext(context.Background(), groupID)
+}
 
-	nextPage := "1"
-	combinedResults := ""
-	uri := fmt.Sprintf("/groups/%d/descendant_groups", groupID)
-	for {
-		fetchUri := fmt.Sprintf("https://%s%s%s?page=%s", r.BaseUrl, r.ApiPath, uri, nextPage)
-		// fmt.Printf("fetchUri: %s\n", fetchUri)
-		resp, resperr := r.Client.R().
-			SetHeader("PRIVATE-TOKEN", r.Token).
-			SetHeader("Content-Type", "application/json").
-			Get(fetchUri)
-
-		if resperr != nil {
-			logrus.WithError(resperr).Error("Oops")
-			return GroupList{}, resperr
-		}
-		items := strings.TrimPrefix(string(resp.Body()[:]), "[")
-		items = strings.TrimSuffix(items, "]")
-		if combinedResults == "" {
-			combinedResults += items
-		} else {
-			combinedResults += fmt.Sprintf(", %s", items)
-		}
-		currentPage := resp.Header().Get("X-Page")
-		nextPage = resp.Header().Get("X-Next-Page")
-		totalPages := resp.Header().Get("X-Total-Pages")
-		if currentPage == totalPages {
-			break
-		}
+// GetDescendantGroupsWithContext - GetDescendantGroups bound to ctx
+func (r *gitlabClient) GetDescendantGroupsWithContext(ctx context.Context, groupID int) (GroupList, error) {
+	return r.ListDescendantGroupsWithContext(ctx, groupID, nil)
+}
+
+// ListGroupProjectsOptions filters ListGroupProjects
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/groups.html#list-a-groups-projects
+type ListGroupProjectsOptions struct {
+	PaginationOptions
+	SortOptions
+	Archived         *bool  `url:"archived,omitempty"`
+	Visibility       string `url:"visibility,omitempty"`
+	Search           string `url:"search,omitempty"`
+	Simple           *bool  `url:"simple,omitempty"`
+	Owned            *bool  `url:"owned,omitempty"`
+	Starred          *bool  `url:"starred,omitempty"`
+	IncludeSubGroups *bool  `url:"include_subgroups,omitempty"`
+	WithShared       *bool  `url:"with_shared,omitempty"`
+	MinAccessLevel   int    `url:"min_access_level,omitempty"`
+}
+
+// ListGroupProjects - returns the projects of groupID, filtered by opts
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/groups.html#list-a-groups-projects
+func (r *gitlabClient) ListGroupProjects(groupID int, opts *ListGroupProjectsOptions) (ProjectList, error) {
+	return r.ListGroupProjectsWithContext(context.Background(), groupID, opts)
+}
+
+// ListGroupProjectsWithContext - ListGroupProjects bound to ctx
+func (r *gitlabClient) ListGroupProjectsWithContext(ctx context.Context, groupID int, opts *ListGroupProjectsOptions) (ProjectList, error) {
+
+	if opts == nil {
+		opts = &ListGroupProjectsOptions{}
+	}
+	uri, err := withQuery(fmt.Sprintf("/groups/%d/projects", groupID), opts)
+	if err != nil {
+		return ProjectList{}, err
 	}
-	surroundArray := fmt.Sprintf("[%s]", combinedResults)
-	var gl GroupList
-	marshErr := json.Unmarshal([]byte(surroundArray), &gl)
-	if marshErr != nil {
-		logrus.Fatal("Cannot marshall Pipeline", marshErr)
-		return GroupList{}, marshErr
+	pl := ProjectList{}
+	if err := r.listAll(ctx, uri, opts.pager(), &pl); err != nil {
+		return ProjectList{}, err
 	}
 
-	return gl, nil
+	return pl, nil
+
 }
 
 // GetGroupProjects- returns a list of projects for a given group id
@@ -212,82 +406,63 @@ func (r *gitlabClient) GetDescendantGroups(groupID int) (GroupList, error) {
 // GitLab API docs:
 // https://docs.gitlab.com/ee/api/groups.html#list-a-groups-projects
 func (r *gitlabClient) GetGroupProjects(groupID int) (ProjectList, error) {
+	return r.GetGroupProjectsWithContext(context.Background(), groupID)
+}
 
-	nextPage := "1"
-	combinedResults := ""
-	uri := fmt.Sprintf("/groups/%d/projects", groupID)
-	for {
-		fetchUri := fmt.Sprintf("https://%s%s%s?page=%s", r.BaseUrl, r.ApiPath, uri, nextPage)
-		// fmt.Printf("fetchUri: %s\n", fetchUri)
-		resp, resperr := r.Client.R().
-			SetHeader("PRIVATE-TOKEN", r.Token).
-			SetHeader("Content-Type", "application/json").
-			Get(fetchUri)
-
-		if resperr != nil {
-			logrus.WithError(resperr).Error("Oops")
-			return ProjectList{}, resperr
-		}
-		items := strings.TrimPrefix(string(resp.Body()[:]), "[")
-		items = strings.TrimSuffix(items, "]")
-		if combinedResults == "" {
-			combinedResults += items
-		} else {
-			combinedResults += fmt.Sprintf(", %s", items)
-		}
-		currentPage := resp.Header().Get("X-Page")
-		nextPage = resp.Header().Get("X-Next-Page")
-		totalPages := resp.Header().Get("X-Total-Pages")
-		if currentPage == totalPages {
-			break
-		}
-	}
-	surroundArray := fmt.Sprintf("[%s]", combinedResults)
-	var pl ProjectList
-	marshErr := json.Unmarshal([]byte(surroundArray), &pl)
-	if marshErr != nil {
-		logrus.Fatal("Cannot marshall Pipeline", marshErr)
-		return ProjectList{}, marshErr
-	}
+// GetGroupProjectsWithContext - GetGroupProjects bound to ctx
+func (r *gitlabClient) GetGroupProjectsWithContext(ctx context.Context, groupID int) (ProjectList, error) {
+	return r.ListGroupProjectsWithContext(ctx, groupID, nil)
+}
 
-	return pl, nil
+// ListMembersOptions filters the group and project member listings
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/members.html#list-all-members-of-a-group-or-project
+type ListMembersOptions struct {
+	PaginationOptions
+	SortOptions
+	Query   string `url:"query,omitempty"`
+	UserIDs []int  `url:"user_ids,omitempty,brackets"`
+	// State is "awaiting" or "active", empty for both
+	State string `url:"state,omitempty"`
+}
 
+// ListGroupMembers - returns the direct members of groupID, filtered by opts
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/members.html#list-all-members-of-a-group-or-project
+func (r *gitlabClient) ListGroupMembers(groupID int, opts *ListMembersOptions) (Members, error) {
+	return r.ListGroupMembersWithContext(context.Background(), groupID, opts)
+}
+
+// ListGroupMembersWithContext - ListGroupMembers bound to ctx
+func (r *gitlabClient) ListGroupMembersWithContext(ctx context.Context, groupID int, opts *ListMembersOptions) (Members, error) {
+	return r.listMembers(ctx, fmt.Sprintf("/groups/%d/members", groupID), opts)
+}
+
+// ListAllGroupMembers - returns the direct, inherited and invited members
+// of groupID, filtered by opts
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/members.html#list-all-members-of-a-group-or-project-including-inherited-and-invited-members
+func (r *gitlabClient) ListAllGroupMembers(groupID int, opts *ListMembersOptions) (Members, error) {
+	return r.ListAllGroupMembersWithContext(context.Background(), groupID, opts)
+}
+
+// ListAllGroupMembersWithContext - ListAllGroupMembers bound to ctx
+func (r *gitlabClient) ListAllGroupMembersWithContext(ctx context.Context, groupID int, opts *ListMembersOptions) (Members, error) {
+	return r.listMembers(ctx, fmt.Sprintf("/groups/%d/members/all", groupID), opts)
 }
 
 // https://docs.gitlab.com/ee/api/members.html#list-all-members-of-a-group-or-project
 func (r *gitlabClient) GetGroupMembers(group int) (string, error) {
+	return r.GetGroupMembersWithContext(context.Background(), group)
+}
 
-	nextPage := "1"
-	combinedResults := ""
-	uri := fmt.Sprintf("/groups/%d/members", group)
-	for {
-		// TODO: detect if there are no options passed in, ? verus & for page option
-		fetchUri := fmt.Sprintf("https://%s%s%s?page=%s", r.BaseUrl, r.ApiPath, uri, nextPage)
-		// logrus.Warn(fetchUri)
-		resp, resperr := r.Client.R().
-			SetHeader("PRIVATE-TOKEN", r.Token).
-			Get(fetchUri)
-
-		if resperr != nil {
-			logrus.WithError(resperr).Error("Oops")
-			return "", resperr
-		}
-
-		items := strings.TrimPrefix(string(resp.Body()[:]), "[")
-		items = strings.TrimSuffix(items, "]")
-		if combinedResults == "" {
-			combinedResults += items
-		} else {
-			combinedResults += fmt.Sprintf(", %s", items)
-		}
-		currentPage := resp.Header().Get("X-Page")
-		nextPage = resp.Header().Get("X-Next-Page")
-		totalPages := resp.Header().Get("X-Total-Pages")
-		if currentPage == totalPages {
-			break
-		}
-	}
-	return fmt.Sprintf("[%s]", combinedResults), nil
+// GetGroupMembersWithContext - GetGroupMembers bound to ctx, see
+// ListGroupMembers for typed results
+func (r *gitlabClient) GetGroupMembersWithContext(ctx context.Context, group int) (string, error) {
+	return r.listRaw(ctx, fmt.Sprintf("/groups/%d/members", group), nil)
 }
 
 // AddGroupMember
@@ -295,25 +470,67 @@ func (r *gitlabClient) GetGroupMembers(group int) (string, error) {
 // GitLab API docs:
 // https://docs.gitlab.com/ee/api/members.html#add-a-member-to-a-group-or-project
 func (r *gitlabClient) AddGroupMember(groupID, userID, accessLevel int) (string, error) {
+	return r.AddGroupMemberWithContext(context.Background(), groupID, userID, accessLevel)
+}
 
-	uri := fmt.Sprintf("/groups/%d/members", groupID)
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	memberTemplate := `{
-			"user_id": "%d",
-			"access_level": "%d"
-			}`
-	body := fmt.Sprintf(memberTemplate, userID, accessLevel)
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		SetBody(body).
-		Post(fetchUri)
+// AddGroupMemberWithContext - AddGroupMember bound to ctx
+func (r *gitlabClient) AddGroupMemberWithContext(ctx context.Context, groupID, userID, accessLevel int) (string, error) {
 
+	uri := fmt.Sprintf("/groups/%d/members", groupID)
+	body := addMemberRequest{
+		UserID:      userID,
+		AccessLevel: AccessLevel(accessLevel),
+	}
+	resp, resperr := r.do(ctx, http.MethodPost, uri, body, nil)
 	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
 		return "", resperr
 	}
 
 	return string(resp.Body()[:]), nil
 
 }
+
+// AddGroupMemberOpts - adds userID to groupID with the access level
+// and expiry in opts
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/members.html#add-a-member-to-a-group-or-project
+func (r *gitlabClient) AddGroupMemberOpts(groupID, userID int, opts MemberOptions) (Member, error) {
+	return r.AddGroupMemberOptsWithContext(context.Background(), groupID, userID, opts)
+}
+
+// AddGroupMemberOptsWithContext - AddGroupMemberOpts bound to ctx
+func (r *gitlabClient) AddGroupMemberOptsWithContext(ctx context.Context, groupID, userID int, opts MemberOptions) (Member, error) {
+	return r.addMember(ctx, fmt.Sprintf("/groups/%d/members", groupID), userID, opts)
+}
+
+// UpdateGroupMember - changes the access level and/or expiry of a direct
+// member of groupID
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/members.html#edit-a-member-of-a-group-or-project
+func (r *gitlabClient) UpdateGroupMember(groupID, userID int, opts MemberOptions) (Member, error) {
+	return r.UpdateGroupMemberWithContext(context.Background(), groupID, userID, opts)
+}
+
+// UpdateGroupMemberWithContext - UpdateGroupMember bound to ctx
+func (r *gitlabClient) UpdateGroupMemberWithContext(ctx context.Context, groupID, userID int, opts MemberOptions) (Member, error) {
+	return r.updateMember(ctx, fmt.Sprintf("/groups/%d/members/%d", groupID, userID), opts)
+}
+
+// RemoveGroupMember - removes a direct member of groupID
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/members.html#remove-a-member-from-a-group-or-project
+func (r *gitlabClient) RemoveGroupMember(groupID, userID int) error {
+	return r.RemoveGroupMemberWithContext(context.Background(), groupID, userID)
+}
+
+// RemoveGroupMemberWithContext - RemoveGroupMember bound to ctx
+func (r *gitlabClient) RemoveGroupMemberWithContext(ctx context.Context, groupID, userID int) error {
+
+	uri := fmt.Sprintf("/groups/%d/members/%d", groupID, userID)
+	_, resperr := r.do(ctx, http.MethodDelete, uri, nil, nil)
+	return resperr
+
+}
@@ -0,0 +1,158 @@
+package gitlab_test
+
+import (
+	"testing"
+	"time"
+
+	gitlab "github.com/maahsome/gitlab-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateGroup(t *testing.T) {
+
+	_, client := newFakeClient(t)
+
+	root, err := client.Groups().CreateGroup(gitlab.CreateGroupOptions{
+		Name:                 "Platform",
+		Path:                 "platform",
+		Visibility:           "internal",
+		ProjectCreationLevel: gitlab.ProjectCreationMaintainer,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "platform", root.FullPath)
+	assert.Equal(t, "internal", root.Visibility)
+	assert.Equal(t, gitlab.ProjectCreationMaintainer, root.ProjectCreationLevel)
+
+	sub, err := client.Groups().CreateGroup(gitlab.CreateGroupOptions{Name: "Web", Path: "web", ParentID: root.ID, LfsEnabled: gitlab.Bool(true)})
+	require.NoError(t, err)
+	assert.Equal(t, "platform/web", sub.FullPath)
+	assert.Equal(t, "Platform / Web", sub.FullName)
+	assert.Equal(t, "private", sub.Visibility)
+	assert.True(t, sub.LfsEnabled)
+
+	_, err = client.Groups().CreateGroup(gitlab.CreateGroupOptions{Name: "Web", Path: "web", ParentID: root.ID})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "path has already been taken")
+
+	_, err = client.Groups().CreateGroup(gitlab.CreateGroupOptions{Name: "X", Path: "x", Visibility: "secret"})
+	assert.Contains(t, err.Error(), "visibility does not have a valid value")
+
+	_, err = client.Groups().CreateGroup(gitlab.CreateGroupOptions{Name: "X", Path: "x", ParentID: 999})
+	assert.True(t, gitlab.IsNotFound(err))
+}
+
+func TestUpdateGroupRenamesDescendants(t *testing.T) {
+
+	fake, client := newFakeClient(t)
+	root := fake.AddGroup(gitlab.Group{Path: "platform", Name: "Platform"})
+	sub := fake.AddGroup(gitlab.Group{Path: "web", Name: "Web", ParentID: root.ID})
+	project := fake.AddProject(sub.ID, gitlab.Project{Path: "site", Name: "site"})
+
+	updated, err := client.Groups().UpdateGroup(root.ID, gitlab.UpdateGroupOptions{
+		Path:        gitlab.String("plat"),
+		Description: gitlab.String("shared services"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "plat", updated.FullPath)
+	assert.Equal(t, "shared services", updated.Description)
+
+	g, _ := fake.Group(sub.ID)
+	assert.Equal(t, "plat/web", g.FullPath)
+	p, _ := fake.Project(project.ID)
+	assert.Equal(t, "plat/web/site", p.PathWithNamespace)
+}
+
+func TestTransferGroup(t *testing.T) {
+
+	fake, client := newFakeClient(t)
+	root := fake.AddGroup(gitlab.Group{Path: "platform", Name: "Platform"})
+	sub := fake.AddGroup(gitlab.Group{Path: "web", Name: "Web", ParentID: root.ID})
+	other := fake.AddGroup(gitlab.Group{Path: "other", Name: "Other"})
+	project := fake.AddProject(sub.ID, gitlab.Project{Path: "site", Name: "site"})
+
+	moved, err := client.Groups().TransferGroup(sub.ID, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "other/web", moved.FullPath)
+	p, _ := fake.Project(project.ID)
+	assert.Equal(t, "other/web/site", p.PathWithNamespace)
+
+	_, err = client.Groups().TransferGroup(other.ID, sub.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Cannot transfer group to one of its subgroup")
+
+	top, err := client.Groups().TransferGroup(sub.ID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "web", top.FullPath)
+	assert.Zero(t, top.ParentID)
+}
+
+func TestShareGroup(t *testing.T) {
+
+	fake, client := newFakeClient(t)
+	g := fake.AddGroup(gitlab.Group{Path: "platform", Name: "Platform"})
+	security := fake.AddGroup(gitlab.Group{Path: "security", Name: "Security"})
+
+	shared, err := client.Groups().ShareGroup(g.ID, security.ID, gitlab.ReporterAccess, gitlab.Date(2030, 1, 31))
+	require.NoError(t, err)
+	require.Len(t, shared.SharedWithGroups, 1)
+	assert.Equal(t, gitlab.ReporterAccess, shared.SharedWithGroups[0].GroupAccessLevel)
+	assert.Equal(t, "2030-01-31", shared.SharedWithGroups[0].ExpiresAt.String())
+
+	_, err = client.Groups().ShareGroup(g.ID, security.ID, gitlab.ReporterAccess, nil)
+	assert.True(t, gitlab.IsConflict(err))
+
+	require.NoError(t, client.Groups().UnshareGroup(g.ID, security.ID))
+	after, _ := fake.Group(g.ID)
+	assert.Empty(t, after.SharedWithGroups)
+}
+
+func TestDeleteGroup(t *testing.T) {
+
+	fake, client := newFakeClient(t)
+	g := fake.AddGroup(gitlab.Group{Path: "platform", Name: "Platform"})
+	project := fake.AddProject(g.ID, gitlab.Project{Path: "site", Name: "site"})
+
+	// without delayed deletion the group is gone by the time it is read back
+	legacy := fake.AddGroup(gitlab.Group{Path: "legacy", Name: "Legacy"})
+	deletion, err := client.Groups().DeleteGroup(legacy.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, gitlab.GroupDeletion{}, deletion)
+
+	fake.DelayedGroupDeletion = true
+	today := time.Now().UTC().Format("2006-01-02")
+
+	dryRun := gitlab.NewDryRun()
+	dryClient, err := fake.Client(gitlab.WithLogger(gitlab.NopLogger()), gitlab.WithDryRun(dryRun))
+	require.NoError(t, err)
+	deletion, err = dryClient.Groups().DeleteGroup(g.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, gitlab.GroupDeletion{DryRun: true}, deletion)
+	marked, _ := fake.Group(g.ID)
+	assert.Nil(t, marked.MarkedForDeletionOn, "a dry run must not mark the group")
+
+	deletion, err = client.Groups().DeleteGroup(g.ID, nil)
+	require.NoError(t, err)
+	assert.True(t, deletion.Scheduled)
+	assert.Equal(t, today, deletion.MarkedForDeletionOn.String())
+	_, err = client.Groups().RestoreGroup(g.ID)
+	require.NoError(t, err)
+	restored, _ := fake.Group(g.ID)
+	assert.Nil(t, restored.MarkedForDeletionOn)
+
+	deletion, err = client.Groups().DeleteGroup(g.ID, nil)
+	require.NoError(t, err)
+	assert.True(t, deletion.Scheduled)
+
+	_, err = client.Groups().DeleteGroup(g.ID, &gitlab.DeleteGroupOptions{PermanentlyRemove: true, FullPath: "wrong"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "full_path")
+
+	deletion, err = client.Groups().DeleteGroup(g.ID, &gitlab.DeleteGroupOptions{PermanentlyRemove: true, FullPath: "platform"})
+	require.NoError(t, err)
+	assert.False(t, deletion.Scheduled)
+	_, err = client.GetGroup(g.ID)
+	assert.True(t, gitlab.IsNotFound(err))
+	_, err = client.GetProject(project.ID)
+	assert.True(t, gitlab.IsNotFound(err))
+}
@@ -0,0 +1,65 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ListJobsOptions filters ListPipelineJobs
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/jobs.html#list-pipeline-jobs
+type ListJobsOptions struct {
+	PaginationOptions
+	Scope          []string `url:"scope,omitempty,brackets"`
+	IncludeRetried *bool    `url:"include_retried,omitempty"`
+}
+
+// ListPipelineJobs - returns the jobs of a pipeline, filtered by opts
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/jobs.html#list-pipeline-jobs
+func (r *gitlabClient) ListPipelineJobs(projectID int, pipelineID int, opts *ListJobsOptions) (Jobs, error) {
+	return r.ListPipelineJobsWithContext(context.Background(), projectID, pipelineID, opts)
+}
+
+// ListPipelineJobsWithContext - ListPipelineJobs bound to ctx
+func (r *gitlabClient) ListPipelineJobsWithContext(ctx context.Context, projectID int, pipelineID int, opts *ListJobsOptions) (Jobs, error) {
+
+	if opts == nil {
+		opts = &ListJobsOptions{}
+	}
+	uri, err := withQuery(fmt.Sprintf("/projects/%d/pipelines/%d/jobs", projectID, pipelineID), opts)
+	if err != nil {
+		return Jobs{}, err
+	}
+	jobs := Jobs{}
+	if err := r.listAll(ctx, uri, opts.pager(), &jobs); err != nil {
+		return Jobs{}, err
+	}
+
+	return jobs, nil
+
+}
+
+// GetJob - Returns a single job
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/jobs.html#get-a-single-job
+func (r *gitlabClient) GetJob(projectID int, jobID int) (Job, error) {
+	return r.GetJobWithContext(context.Background(), projectID, jobID)
+}
+
+// GetJobWithContext - GetJob bound to ctx
+func (r *gitlabClient) GetJobWithContext(ctx context.Context, projectID int, jobID int) (Job, error) {
+
+	uri := fmt.Sprintf("/projects/%d/jobs/%d", projectID, jobID)
+	var job Job
+	if _, err := r.do(ctx, http.MethodGet, uri, nil, &job); err != nil {
+		return Job{}, err
+	}
+
+	return job, nil
+
+}
@@ -0,0 +1,191 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+)
+
+// listMembers lists the members endpoint uri of a group or project
+func (r *gitlabClient) listMembers(ctx context.Context, uri string, opts *ListMembersOptions) (Members, error) {
+
+	if opts == nil {
+		opts = &ListMembersOptions{}
+	}
+	uri, err := withQuery(uri, opts)
+	if err != nil {
+		return Members{}, err
+	}
+	members := Members{}
+	if err := r.listAll(ctx, uri, opts.pager(), &members); err != nil {
+		return Members{}, err
+	}
+
+	return members, nil
+
+}
+
+// addMember POSTs userID to the members endpoint uri of a group or project
+func (r *gitlabClient) addMember(ctx context.Context, uri string, userID int, opts MemberOptions) (Member, error) {
+
+	body := addMemberRequest{
+		UserID:      userID,
+		AccessLevel: opts.AccessLevel,
+		ExpiresAt:   opts.ExpiresAt,
+	}
+	var member Member
+	if _, err := r.do(ctx, http.MethodPost, uri, body, &member); err != nil {
+		return Member{}, err
+	}
+
+	return member, nil
+
+}
+
+// updateMember PUTs opts to the member uri of a group or project.  GitLab
+// requires access_level, so the current level is looked up when opts has
+// none.
+func (r *gitlabClient) updateMember(ctx context.Context, uri string, opts MemberOptions) (Member, error) {
+
+	if opts.ClearExpiry && opts.ExpiresAt != nil {
+		return Member{}, errors.New("set either ExpiresAt or ClearExpiry, not both")
+	}
+	body := updateMemberRequest{AccessLevel: opts.AccessLevel}
+	switch {
+	case opts.ExpiresAt != nil:
+		expires := opts.ExpiresAt.String()
+		body.ExpiresAt = &expires
+	case opts.ClearExpiry:
+		body.ExpiresAt = String("")
+	}
+	if body.AccessLevel == NoAccess {
+		var current Member
+		if _, err := r.do(ctx, http.MethodGet, uri, nil, &current); err != nil {
+			return Member{}, err
+		}
+		body.AccessLevel = current.AccessLevel
+	}
+
+	var member Member
+	if _, err := r.do(ctx, http.MethodPut, uri, body, &member); err != nil {
+		return Member{}, err
+	}
+
+	return member, nil
+
+}
+
+// GetProjectAccess - who has access to projectID and why.  Every user is
+// listed with their effective access level and each membership granting
+// it: direct project membership, membership of the project's group or one
+// of its ancestors, and membership of a group that the project, or one of
+// those ancestors, is shared with.  Access through a share is capped at the
+// share's access level.
+func (r *gitlabClient) GetProjectAccess(projectID int) (ProjectAccess, error) {
+	return r.GetProjectAccessWithContext(context.Background(), projectID)
+}
+
+// GetProjectAccessWithContext - GetProjectAccess bound to ctx
+func (r *gitlabClient) GetProjectAccessWithContext(ctx context.Context, projectID int) (ProjectAccess, error) {
+
+	project, err := r.GetProjectWithContext(ctx, projectID)
+	if err != nil {
+		return ProjectAccess{}, err
+	}
+
+	report := accessReport{users: map[int]*UserAccess{}}
+
+	direct, err := r.ListProjectMembersWithContext(ctx, projectID, nil)
+	if err != nil {
+		return ProjectAccess{}, err
+	}
+	for _, m := range direct {
+		report.add(m, AccessSource{Kind: AccessDirect, Path: project.PathWithNamespace, AccessLevel: m.AccessLevel, ExpiresAt: m.ExpiresAt})
+	}
+	if err := r.addSharedAccess(ctx, &report, project.SharedWithGroups, project.PathWithNamespace); err != nil {
+		return ProjectAccess{}, err
+	}
+
+	// walk up from the project's namespace, user namespaces have no
+	// members of their own
+	groupID := 0
+	if project.Namespace.Kind == "group" {
+		groupID = project.Namespace.ID
+	}
+	seen := map[int]bool{}
+	for groupID != 0 && !seen[groupID] {
+		seen[groupID] = true
+		group, err := r.GetGroupWithContext(ctx, groupID)
+		if err != nil {
+			return ProjectAccess{}, err
+		}
+		members, err := r.ListGroupMembersWithContext(ctx, groupID, nil)
+		if err != nil {
+			return ProjectAccess{}, err
+		}
+		for _, m := range members {
+			report.add(m, AccessSource{Kind: AccessInherited, Path: group.FullPath, AccessLevel: m.AccessLevel, ExpiresAt: m.ExpiresAt})
+		}
+		if err := r.addSharedAccess(ctx, &report, group.SharedWithGroups, group.FullPath); err != nil {
+			return ProjectAccess{}, err
+		}
+		groupID = group.ParentID
+	}
+
+	return report.result(), nil
+
+}
+
+// addSharedAccess adds the members of every group in shares, which sharedWith
+// is shared with, to report
+func (r *gitlabClient) addSharedAccess(ctx context.Context, report *accessReport, shares []SharedGroup, sharedWith string) error {
+
+	for _, share := range shares {
+		members, err := r.ListAllGroupMembersWithContext(ctx, share.GroupID, nil)
+		if err != nil {
+			return err
+		}
+		for _, m := range members {
+			level := m.AccessLevel
+			if share.GroupAccessLevel < level {
+				level = share.GroupAccessLevel
+			}
+			expires := share.ExpiresAt
+			if m.ExpiresAt != nil && (expires == nil || m.ExpiresAt.String() < expires.String()) {
+				expires = m.ExpiresAt
+			}
+			report.add(m, AccessSource{Kind: AccessShared, Path: share.GroupFullPath, SharedWith: sharedWith, AccessLevel: level, ExpiresAt: expires})
+		}
+	}
+	return nil
+}
+
+// accessReport collects the memberships of each user for GetProjectAccess
+type accessReport struct {
+	users map[int]*UserAccess
+}
+
+func (a *accessReport) add(m Member, source AccessSource) {
+	ua, ok := a.users[m.ID]
+	if !ok {
+		ua = &UserAccess{UserID: m.ID, Username: m.Username, Name: m.Name}
+		a.users[m.ID] = ua
+	}
+	ua.Sources = append(ua.Sources, source)
+	if source.AccessLevel > ua.AccessLevel {
+		ua.AccessLevel = source.AccessLevel
+	}
+}
+
+// result is the report ordered by username
+func (a *accessReport) result() ProjectAccess {
+	access := make(ProjectAccess, 0, len(a.users))
+	for _, ua := range a.users {
+		access = append(access, *ua)
+	}
+	sort.Slice(access, func(i, j int) bool {
+		return access[i].Username < access[j].Username
+	})
+	return access
+}
@@ -0,0 +1,38 @@
+package gitlab_test
+
+import (
+	"context"
+	"testing"
+
+	gitlab "github.com/maahsome/gitlab-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateGroupMember(t *testing.T) {
+
+	fake, client := newFakeClient(t)
+	ctx := context.Background()
+	alice := fake.AddUser("alice", "Alice")
+	g := fake.AddGroup(gitlab.Group{Path: "platform", Name: "Platform"})
+	fake.AddMember("groups", g.ID, alice, int(gitlab.DeveloperAccess))
+
+	// GitLab requires access_level, an expiry-only update keeps the level
+	m, err := client.Members().UpdateGroupMemberWithContext(ctx, g.ID, alice, gitlab.MemberOptions{ExpiresAt: gitlab.Date(2030, 1, 31)})
+	require.NoError(t, err)
+	assert.Equal(t, gitlab.DeveloperAccess, m.AccessLevel)
+	assert.Equal(t, "2030-01-31", m.ExpiresAt.String())
+
+	m, err = client.Members().UpdateGroupMemberWithContext(ctx, g.ID, alice, gitlab.MemberOptions{AccessLevel: gitlab.MaintainerAccess})
+	require.NoError(t, err)
+	assert.Equal(t, gitlab.MaintainerAccess, m.AccessLevel)
+	assert.NotNil(t, m.ExpiresAt, "an update without an expiry keeps it")
+
+	m, err = client.Members().UpdateGroupMemberWithContext(ctx, g.ID, alice, gitlab.MemberOptions{ClearExpiry: true})
+	require.NoError(t, err)
+	assert.Equal(t, gitlab.MaintainerAccess, m.AccessLevel)
+	assert.Nil(t, m.ExpiresAt)
+
+	_, err = client.Members().UpdateGroupMemberWithContext(ctx, g.ID, 999, gitlab.MemberOptions{ClearExpiry: true})
+	assert.True(t, gitlab.IsNotFound(err))
+}
@@ -1,92 +1,93 @@
 package gitlab
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"strings"
-
-	"github.com/sirupsen/logrus"
+	"net/http"
+	"time"
 )
 
-// GetPipelines returns a list of pipelines for the project
+// ListPipelinesOptions filters ListPipelines
 //
 // GitLab API docs:
 // https://docs.gitlab.com/ee/api/pipelines.html#list-project-pipelines
-func (r *gitlabClient) GetPipelines(projectID int, user string, limit int) (Pipelines, error) {
+type ListPipelinesOptions struct {
+	PaginationOptions
+	SortOptions
+	Scope         string     `url:"scope,omitempty"`
+	Status        string     `url:"status,omitempty"`
+	Source        string     `url:"source,omitempty"`
+	Ref           string     `url:"ref,omitempty"`
+	SHA           string     `url:"sha,omitempty"`
+	YamlErrors    *bool      `url:"yaml_errors,omitempty"`
+	Username      string     `url:"username,omitempty"`
+	UpdatedAfter  *time.Time `url:"updated_after,omitempty"`
+	UpdatedBefore *time.Time `url:"updated_before,omitempty"`
+}
 
-	nextPage := "1"
-	combinedResults := ""
-	uri := ""
-	if len(user) > 0 {
-		uri = fmt.Sprintf("/projects/%d/pipelines?per_page=%d&username=%s", projectID, limit, user)
-	} else {
-		uri = fmt.Sprintf("/projects/%d/pipelines?per_page=%d", projectID, limit)
-	}
-	// for {
-	fetchUri := fmt.Sprintf("https://%s%s%s&page=%s", r.BaseUrl, r.ApiPath, uri, nextPage)
-	// fmt.Printf("fetchUri: %s\n", fetchUri)
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		Get(fetchUri)
+// ListPipelines - returns the pipelines of projectID, filtered by opts
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/pipelines.html#list-project-pipelines
+func (r *gitlabClient) ListPipelines(projectID int, opts *ListPipelinesOptions) (Pipelines, error) {
+	return r.ListPipelinesWithContext(context.Background(), projectID, opts)
+}
+
+// ListPipelinesWithContext - ListPipelines bound to ctx
+func (r *gitlabClient) ListPipelinesWithContext(ctx context.Context, projectID int, opts *ListPipelinesOptions) (Pipelines, error) {
 
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
-		return Pipelines{}, resperr
+	if opts == nil {
+		opts = &ListPipelinesOptions{}
 	}
-	items := strings.TrimPrefix(string(resp.Body()[:]), "[")
-	items = strings.TrimSuffix(items, "]")
-	if combinedResults == "" {
-		combinedResults += items
-	} else {
-		combinedResults += fmt.Sprintf(", %s", items)
+	uri, err := withQuery(fmt.Sprintf("/projects/%d/pipelines", projectID), opts)
+	if err != nil {
+		return Pipelines{}, err
 	}
-	// TODO: add logic to request MORE than the normal limit, and re-enable
-	//       the nextPage feature
-	// currentPage := resp.Header().Get("X-Page")
-	// nextPage = resp.Header().Get("X-Next-Page")
-	// totalPages := resp.Header().Get("X-Total-Pages")
-	// break
-	// if currentPage == totalPages {
-	// 	break
-	// }
-	// }
-	surroundArray := fmt.Sprintf("[%s]", combinedResults)
-	var pipelines Pipelines
-	marshErr := json.Unmarshal([]byte(surroundArray), &pipelines)
-	if marshErr != nil {
-		logrus.Fatal("Cannot marshall Pipeline", marshErr)
-		return Pipelines{}, marshErr
+	pipelines := Pipelines{}
+	if err := r.listAll(ctx, uri, opts.pager(), &pipelines); err != nil {
+		return Pipelines{}, err
 	}
 
 	return pipelines, nil
 
 }
 
+// GetPipelines returns the most recent limit (default 20) pipelines for the
+// project
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/pipelines.html#list-project-pipelines
+func (r *gitlabClient) GetPipelines(projectID int, user string, limit int) (Pipelines, error) {
+	return r.GetPipelinesWithContext(context.Background(), projectID, user, limit)
+}
+
+// GetPipelinesWithContext - GetPipelines bound to ctx
+func (r *gitlabClient) GetPipelinesWithContext(ctx context.Context, projectID int, user string, limit int) (Pipelines, error) {
+
+	if limit <= 0 {
+		limit = 20
+	}
+	return r.ListPipelinesWithContext(ctx, projectID, &ListPipelinesOptions{
+		PaginationOptions: PaginationOptions{PerPage: limit, MaxItems: limit},
+		Username:          user,
+	})
+}
+
 // GetPipeline - Returns a single pipeline
 //
 // GitLab API docs:
 // https://docs.gitlab.com/ee/api/pipelines.html#get-a-single-pipeline
 func (r *gitlabClient) GetPipeline(projectID int, pipelineID int) (Pipeline, error) {
+	return r.GetPipelineWithContext(context.Background(), projectID, pipelineID)
+}
 
-	uri := fmt.Sprintf("/projects/%d/pipelines/%d", projectID, pipelineID)
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	// fmt.Printf("fetchUri: %s\n", fetchUri)
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		Get(fetchUri)
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
-		return Pipeline{}, resperr
-	}
+// GetPipelineWithContext - GetPipeline bound to ctx
+func (r *gitlabClient) GetPipelineWithContext(ctx context.Context, projectID int, pipelineID int) (Pipeline, error) {
 
+	uri := fmt.Sprintf("/projects/%d/pipelines/%d", projectID, pipelineID)
 	var pipeline Pipeline
-	marshErr := json.Unmarshal(resp.Body(), &pipeline)
-	if marshErr != nil {
-		logrus.Fatal("Cannot marshall Pipeline", marshErr)
-		return Pipeline{}, resperr
+	if _, err := r.do(ctx, http.MethodGet, uri, nil, &pipeline); err != nil {
+		return Pipeline{}, err
 	}
 
 	return pipeline, nil
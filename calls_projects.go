@@ -1,37 +1,33 @@
 package gitlab
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
-	"strings"
-
-	"github.com/sirupsen/logrus"
+	"net/http"
 )
 
-// GetProjectID - returns the project ID based on the group/project path (slug)
+// GetProjectID - returns the project ID based on the group/project path (slug),
+// e.g. "group/sub/project", which is escaped by the client.  Pass the path
+// unescaped: an already escaped "group%2Fsub%2Fproject" is escaped again and
+// not found.
 //
 // GitLab API docs:
 // https://docs.gitlab.com/ee/api/projects.html#get-single-project
 func (r *gitlabClient) GetProjectID(projectPath string) (int, error) {
+	return r.GetProjectIDWithContext(context.Background(), projectPath)
+}
 
-	uri := fmt.Sprintf("/projects/%s", projectPath)
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	// fmt.Printf("fetchUri: %s\n", fetchUri)
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		Get(fetchUri)
+// GetProjectIDWithContext - GetProjectID bound to ctx
+func (r *gitlabClient) GetProjectIDWithContext(ctx context.Context, projectPath string) (int, error) {
 
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
-		return 0, resperr
+	pid, err := pathID(projectPath)
+	if err != nil {
+		return 0, err
 	}
-
+	uri := fmt.Sprintf("/projects/%s", pid)
 	var pi ProjectInfo
-	marshErr := json.Unmarshal(resp.Body(), &pi)
-	if marshErr != nil {
-		return 0, errors.New(fmt.Sprintf("Error unmarshalling Project Response, %e", marshErr))
+	if _, err := r.do(ctx, http.MethodGet, uri, nil, &pi); err != nil {
+		return 0, err
 	}
 
 	return pi.ID, nil
@@ -43,25 +39,16 @@ func (r *gitlabClient) GetProjectID(projectPath string) (int, error) {
 // GitLab API docs:
 // https://docs.gitlab.com/ee/api/projects.html#get-single-project
 func (r *gitlabClient) GetProject(projectID int) (Project, error) {
+	return r.GetProjectWithContext(context.Background(), projectID)
+}
 
-	uri := fmt.Sprintf("/projects/%d", projectID)
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	// fmt.Printf("fetchUri: %s\n", fetchUri)
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		Get(fetchUri)
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
-		return Project{}, resperr
-	}
+// GetProjectWithContext - GetProject bound to ctx
+func (r *gitlabClient) GetProjectWithContext(ctx context.Context, projectID int) (Project, error) {
 
+	uri := fmt.Sprintf("/projects/%d", projectID)
 	var pi Project
-	marshErr := json.Unmarshal(resp.Body(), &pi)
-	if marshErr != nil {
-		logrus.Fatal("Cannot marshall Pipeline", marshErr)
-		return Project{}, resperr
+	if _, err := r.do(ctx, http.MethodGet, uri, nil, &pi); err != nil {
+		return Project{}, err
 	}
 
 	return pi, nil
@@ -73,6 +60,11 @@ func (r *gitlabClient) GetProject(projectID int) (Project, error) {
 // GitLab API docs:
 // https://docs.gitlab.com/ee/api/projects.html#create-project
 func (r *gitlabClient) CreateProject(groupID int, projectPath string, visibility string) (Project, error) {
+	return r.CreateProjectWithContext(context.Background(), groupID, projectPath, visibility)
+}
+
+// CreateProjectWithContext - CreateProject bound to ctx
+func (r *gitlabClient) CreateProjectWithContext(ctx context.Context, groupID int, projectPath string, visibility string) (Project, error) {
 	// curl -Ls --request POST https://gitlab.com/api/v4/projects --header "PRIVATE-TOKEN: ${mytoken}" \
 	//  --header 'Content-Type: application/json' \
 	//  --data "{
@@ -84,36 +76,20 @@ func (r *gitlabClient) CreateProject(groupID int, projectPath string, visibility
 	//   }"
 
 	uri := "/projects"
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	// logrus.Info(fmt.Sprintf("fetchUri: %s", fetchUri))
-	projectTemplate := `{
-			"path": "%s",
-			"default_branch": "master",
-			"initialize_with_readme": "true",
-			"visibility": "%s",
-			"namespace_id": "%d"
-			}`
-	body := fmt.Sprintf(projectTemplate, projectPath, visibility, groupID)
-	// logrus.Info(fmt.Sprintf("body: %s", body))
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		SetBody(body).
-		Post(fetchUri)
-
+	body := createProjectRequest{
+		Path:                 projectPath,
+		DefaultBranch:        "master",
+		InitializeWithReadme: true,
+		Visibility:           visibility,
+		NamespaceID:          groupID,
+	}
+	var prj Project
+	_, resperr := r.do(ctx, http.MethodPost, uri, body, &prj)
 	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
 		return Project{}, resperr
 	}
 
-	logrus.Info(fmt.Sprintf("%s", string(resp.Body()[:])))
-
-	var prj Project
-	marshErr := json.Unmarshal(resp.Body(), &prj)
-	if marshErr != nil {
-		logrus.Fatal("Cannot marshall Pipeline", marshErr)
-		return Project{}, marshErr
-	}
+	r.logger.Info("project created", "id", prj.ID, "path", prj.PathWithNamespace)
 
 	return prj, nil
 
@@ -124,65 +100,99 @@ func (r *gitlabClient) CreateProject(groupID int, projectPath string, visibility
 // GitLab API docs:
 // https://docs.gitlab.com/ee/api/projects.html#delete-project
 func (r *gitlabClient) DeleteProject(projectID int) error {
+	return r.DeleteProjectWithContext(context.Background(), projectID)
+}
+
+// DeleteProjectWithContext - DeleteProject bound to ctx
+func (r *gitlabClient) DeleteProjectWithContext(ctx context.Context, projectID int) error {
 
 	uri := fmt.Sprintf("/projects/%d", projectID)
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		Delete(fetchUri)
+	_, resperr := r.do(ctx, http.MethodDelete, uri, nil, nil)
+	return resperr
 
-	if resperr != nil {
-		return resperr
-	}
+}
 
-	var msg Message
-	marshErr := json.Unmarshal(resp.Body(), &msg)
-	if marshErr != nil {
-		return marshErr
-	}
+// ShareProject - gives the members of sharedWithGroupID access to
+// projectID, at most accessLevel, until expiresAt when it is not nil
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/projects.html#share-project-with-group
+func (r *gitlabClient) ShareProject(projectID int, sharedWithGroupID int, accessLevel AccessLevel, expiresAt *ISODate) (ProjectGroupLink, error) {
+	return r.ShareProjectWithContext(context.Background(), projectID, sharedWithGroupID, accessLevel, expiresAt)
+}
+
+// ShareProjectWithContext - ShareProject bound to ctx
+func (r *gitlabClient) ShareProjectWithContext(ctx context.Context, projectID int, sharedWithGroupID int, accessLevel AccessLevel, expiresAt *ISODate) (ProjectGroupLink, error) {
 
-	if msg.Message == "202 Accepted" {
-		return nil
+	uri := fmt.Sprintf("/projects/%d/share", projectID)
+	body := shareRequest{
+		GroupID:     sharedWithGroupID,
+		GroupAccess: accessLevel,
+		ExpiresAt:   expiresAt,
 	}
-	return errors.New(strings.ToLower(msg.Message))
+	var link ProjectGroupLink
+	if _, err := r.do(ctx, http.MethodPost, uri, body, &link); err != nil {
+		return ProjectGroupLink{}, err
+	}
+
+	return link, nil
+
+}
+
+// UnshareProject - stops sharing projectID with sharedWithGroupID
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/projects.html#delete-a-shared-project-link-within-a-group
+func (r *gitlabClient) UnshareProject(projectID int, sharedWithGroupID int) error {
+	return r.UnshareProjectWithContext(context.Background(), projectID, sharedWithGroupID)
+}
+
+// UnshareProjectWithContext - UnshareProject bound to ctx
+func (r *gitlabClient) UnshareProjectWithContext(ctx context.Context, projectID int, sharedWithGroupID int) error {
+
+	uri := fmt.Sprintf("/projects/%d/share/%d", projectID, sharedWithGroupID)
+	_, resperr := r.do(ctx, http.MethodDelete, uri, nil, nil)
+	return resperr
 
 }
 
 // https://docs.gitlab.com/ee/api/members.html#list-all-members-of-a-group-or-project
 func (r *gitlabClient) GetProjectMembers(project int) (string, error) {
+	return r.GetProjectMembersWithContext(context.Background(), project)
+}
 
-	nextPage := "1"
-	combinedResults := ""
-	uri := fmt.Sprintf("/projects/%d/members", project)
-	for {
-		// TODO: detect if there are no options passed in, ? verus & for page option
-		fetchUri := fmt.Sprintf("https://%s%s%s?page=%s", r.BaseUrl, r.ApiPath, uri, nextPage)
-		// logrus.Warn(fetchUri)
-		resp, resperr := r.Client.R().
-			SetHeader("PRIVATE-TOKEN", r.Token).
-			Get(fetchUri)
-
-		if resperr != nil {
-			logrus.WithError(resperr).Error("Oops")
-			return "", resperr
-		}
-
-		items := strings.TrimPrefix(string(resp.Body()[:]), "[")
-		items = strings.TrimSuffix(items, "]")
-		if combinedResults == "" {
-			combinedResults += items
-		} else {
-			combinedResults += fmt.Sprintf(", %s", items)
-		}
-		currentPage := resp.Header().Get("X-Page")
-		nextPage = resp.Header().Get("X-Next-Page")
-		totalPages := resp.Header().Get("X-Total-Pages")
-		if currentPage == totalPages {
-			break
-		}
-	}
-	return fmt.Sprintf("[%s]", combinedResults), nil
+// GetProjectMembersWithContext - GetProjectMembers bound to ctx, see
+// ListProjectMembers for typed results
+func (r *gitlabClient) GetProjectMembersWithContext(ctx context.Context, project int) (string, error) {
+	return r.listRaw(ctx, fmt.Sprintf("/projects/%d/members", project), nil)
+}
+
+// ListProjectMembers - returns the direct members of projectID, filtered by
+// opts
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/members.html#list-all-members-of-a-group-or-project
+func (r *gitlabClient) ListProjectMembers(projectID int, opts *ListMembersOptions) (Members, error) {
+	return r.ListProjectMembersWithContext(context.Background(), projectID, opts)
+}
+
+// ListProjectMembersWithContext - ListProjectMembers bound to ctx
+func (r *gitlabClient) ListProjectMembersWithContext(ctx context.Context, projectID int, opts *ListMembersOptions) (Members, error) {
+	return r.listMembers(ctx, fmt.Sprintf("/projects/%d/members", projectID), opts)
+}
+
+// ListAllProjectMembers - returns the direct, inherited and invited members
+// of projectID, filtered by opts
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/members.html#list-all-members-of-a-group-or-project-including-inherited-and-invited-members
+func (r *gitlabClient) ListAllProjectMembers(projectID int, opts *ListMembersOptions) (Members, error) {
+	return r.ListAllProjectMembersWithContext(context.Background(), projectID, opts)
+}
+
+// ListAllProjectMembersWithContext - ListAllProjectMembers bound to ctx
+func (r *gitlabClient) ListAllProjectMembersWithContext(ctx context.Context, projectID int, opts *ListMembersOptions) (Members, error) {
+	return r.listMembers(ctx, fmt.Sprintf("/projects/%d/members/all", projectID), opts)
 }
 
 // AddProjectMember
@@ -190,22 +200,19 @@ func (r *gitlabClient) GetProjectMembers(project int) (string, error) {
 // GitLab API docs:
 // https://docs.gitlab.com/ee/api/members.html#add-a-member-to-a-group-or-project
 func (r *gitlabClient) AddProjectMember(projectID, userID, accessLevel int) (string, error) {
+	return r.AddProjectMemberWithContext(context.Background(), projectID, userID, accessLevel)
+}
 
-	uri := fmt.Sprintf("/projects/%d/members", projectID)
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	memberTemplate := `{
-			"user_id": "%d",
-			"access_level": "%d"
-			}`
-	body := fmt.Sprintf(memberTemplate, userID, accessLevel)
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		SetBody(body).
-		Post(fetchUri)
+// AddProjectMemberWithContext - AddProjectMember bound to ctx
+func (r *gitlabClient) AddProjectMemberWithContext(ctx context.Context, projectID, userID, accessLevel int) (string, error) {
 
+	uri := fmt.Sprintf("/projects/%d/members", projectID)
+	body := addMemberRequest{
+		UserID:      userID,
+		AccessLevel: AccessLevel(accessLevel),
+	}
+	resp, resperr := r.do(ctx, http.MethodPost, uri, body, nil)
 	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
 		return "", resperr
 	}
 
@@ -213,32 +220,68 @@ func (r *gitlabClient) AddProjectMember(projectID, userID, accessLevel int) (str
 
 }
 
+// AddProjectMemberOpts - adds userID to projectID with the access
+// level and expiry in opts
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/members.html#add-a-member-to-a-group-or-project
+func (r *gitlabClient) AddProjectMemberOpts(projectID, userID int, opts MemberOptions) (Member, error) {
+	return r.AddProjectMemberOptsWithContext(context.Background(), projectID, userID, opts)
+}
+
+// AddProjectMemberOptsWithContext - AddProjectMemberOpts bound to ctx
+func (r *gitlabClient) AddProjectMemberOptsWithContext(ctx context.Context, projectID, userID int, opts MemberOptions) (Member, error) {
+	return r.addMember(ctx, fmt.Sprintf("/projects/%d/members", projectID), userID, opts)
+}
+
+// UpdateProjectMember - changes the access level and/or expiry of a direct
+// member of projectID
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/members.html#edit-a-member-of-a-group-or-project
+func (r *gitlabClient) UpdateProjectMember(projectID, userID int, opts MemberOptions) (Member, error) {
+	return r.UpdateProjectMemberWithContext(context.Background(), projectID, userID, opts)
+}
+
+// UpdateProjectMemberWithContext - UpdateProjectMember bound to ctx
+func (r *gitlabClient) UpdateProjectMemberWithContext(ctx context.Context, projectID, userID int, opts MemberOptions) (Member, error) {
+	return r.updateMember(ctx, fmt.Sprintf("/projects/%d/members/%d", projectID, userID), opts)
+}
+
+// RemoveProjectMember - removes a direct member of projectID
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/members.html#remove-a-member-from-a-group-or-project
+func (r *gitlabClient) RemoveProjectMember(projectID, userID int) error {
+	return r.RemoveProjectMemberWithContext(context.Background(), projectID, userID)
+}
+
+// RemoveProjectMemberWithContext - RemoveProjectMember bound to ctx
+func (r *gitlabClient) RemoveProjectMemberWithContext(ctx context.Context, projectID, userID int) error {
+
+	uri := fmt.Sprintf("/projects/%d/members/%d", projectID, userID)
+	_, resperr := r.do(ctx, http.MethodDelete, uri, nil, nil)
+	return resperr
+
+}
+
 // GetProjectMirrors - returns the full project based on the project ID
 //
 // GitLab API docs:
 func (r *gitlabClient) GetProjectMirrors(projectID int) (ProjectMirrors, error) {
+	return r.GetProjectMirrorsWithContext(context.Background(), projectID)
+}
+
+// GetProjectMirrorsWithContext - GetProjectMirrors bound to ctx
+func (r *gitlabClient) GetProjectMirrorsWithContext(ctx context.Context, projectID int) (ProjectMirrors, error) {
 
 	// curl -Ls "https://git.alteryx.com/api/v4/projects/${PR_ID}/remote_mirrors" \
 	//     --header "PRIVATE-TOKEN: ${PRIV_TOKEN}" | jq -r '.[] | ([.id,.url,.enabled,.only_protected_branches]
 
 	uri := fmt.Sprintf("/projects/%d/remote_mirrors", projectID)
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	// fmt.Printf("fetchUri: %s\n", fetchUri)
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		Get(fetchUri)
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
-		return ProjectMirrors{}, resperr
-	}
-
 	var prm ProjectMirrors
-	marshErr := json.Unmarshal(resp.Body(), &prm)
-	if marshErr != nil {
-		logrus.Fatal("Cannot marshall Pipeline", marshErr)
-		return ProjectMirrors{}, resperr
+	if _, err := r.do(ctx, http.MethodGet, uri, nil, &prm); err != nil {
+		return ProjectMirrors{}, err
 	}
 
 	return prm, nil
@@ -249,6 +292,11 @@ func (r *gitlabClient) GetProjectMirrors(projectID int) (ProjectMirrors, error)
 //
 // GitLab API docs:
 func (r *gitlabClient) ProtectBranch(projectID int, protectedBranch string) (bool, error) {
+	return r.ProtectBranchWithContext(context.Background(), projectID, protectedBranch)
+}
+
+// ProtectBranchWithContext - ProtectBranch bound to ctx
+func (r *gitlabClient) ProtectBranchWithContext(ctx context.Context, projectID int, protectedBranch string) (bool, error) {
 
 	// curl -Ls --request POST "https://gitlab.com/api/v4/projects/${PR_ID}/protected_branches" \
 	//          --header "PRIVATE-TOKEN: ${PUB_TOKEN}" \
@@ -277,69 +325,45 @@ func (r *gitlabClient) ProtectBranch(projectID int, protectedBranch string) (boo
 	//                }" | jq '.allow_force_push'
 
 	uri := fmt.Sprintf("/projects/%d/protected_branches", projectID)
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	pbTemplate := `{
-			"name": "%s",
-			"push_access_levels": [
-				{
-					"access_level": 40,
-					"access_level_description": "Maintainers",
-					"user_id": null,
-					"group_id": null
-				}
-			],
-			"merge_access_levels": [
-				{
-					"access_level": 40,
-					"access_level_description": "Maintainers",
-					"user_id": null,
-					"group_id": null
-				}
-			],
-			"allow_force_push": true,
-			"unprotect_access_levels": [],
-			"code_owner_approval_required": false
-			}`
-	body := fmt.Sprintf(pbTemplate, protectedBranch)
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		SetBody(body).
-		Post(fetchUri)
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
-		return false, resperr
+	maintainers := []branchAccessLevel{
+		{
+			AccessLevel:            40,
+			AccessLevelDescription: "Maintainers",
+		},
+	}
+	body := protectBranchRequest{
+		Name:                      protectedBranch,
+		PushAccessLevels:          maintainers,
+		MergeAccessLevels:         maintainers,
+		AllowForcePush:            true,
+		UnprotectAccessLevels:     []branchAccessLevel{},
+		CodeOwnerApprovalRequired: false,
 	}
-
 	var pbs ProtectedBranchSettings
-	marshErr := json.Unmarshal(resp.Body(), &pbs)
-	if marshErr != nil {
-		logrus.Fatal("Cannot marshall Pipeline", marshErr)
-		return false, resperr
+	if _, err := r.do(ctx, http.MethodPost, uri, body, &pbs); err != nil {
+		return false, err
 	}
 
 	return pbs.AllowForcePush, nil
 
 }
 
-// DeleteProtectedBranch - delete the specified branch from the protected list
+// DeleteProtectedBranch - delete the specified branch (or wildcard pattern,
+// e.g. "release/*") from the protected list
 //
 // GitLab API docs:
 func (r *gitlabClient) DeleteProtectedBranch(projectID int, protectedBranch string) (bool, error) {
+	return r.DeleteProtectedBranchWithContext(context.Background(), projectID, protectedBranch)
+}
+
+// DeleteProtectedBranchWithContext - DeleteProtectedBranch bound to ctx
+func (r *gitlabClient) DeleteProtectedBranchWithContext(ctx context.Context, projectID int, protectedBranch string) (bool, error) {
 
 	// curl -Ls --request DELETE "https://gitlab.com/api/v4/projects/${PR_ID}/protected_branches/master" \
 	//      --header "PRIVATE-TOKEN: ${PUB_TOKEN}" | jq -r '.message'
-	uri := fmt.Sprintf("/projects/%d/protected_branches/%s", projectID, protectedBranch)
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	// fmt.Printf("fetchUri: %s\n", fetchUri)
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		Delete(fetchUri)
-
+	uri := fmt.Sprintf("/projects/%d/protected_branches/%s", projectID, pathEscape(protectedBranch))
+	resp, resperr := r.do(ctx, http.MethodDelete, uri, nil, nil)
 	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
 		return false, resperr
 	}
 
@@ -350,6 +374,11 @@ func (r *gitlabClient) DeleteProtectedBranch(projectID int, protectedBranch stri
 //
 // GitLab API docs:
 func (r *gitlabClient) CreateProjectMirror(projectID int, mirrorURL string) (ProjectMirror, error) {
+	return r.CreateProjectMirrorWithContext(context.Background(), projectID, mirrorURL)
+}
+
+// CreateProjectMirrorWithContext - CreateProjectMirror bound to ctx
+func (r *gitlabClient) CreateProjectMirrorWithContext(ctx context.Context, projectID int, mirrorURL string) (ProjectMirror, error) {
 
 	// curl -Ls --request POST "https://git.alteryx.com/api/v4/projects/${PR_ID}/remote_mirrors" \
 	//     --header "PRIVATE-TOKEN: ${PRIV_TOKEN}" \
@@ -361,33 +390,14 @@ func (r *gitlabClient) CreateProjectMirror(projectID int, mirrorURL string) (Pro
 	//     }" | jq -r '([.id,.url,.enabled,.only_protected_branches]) | @csv')
 
 	uri := fmt.Sprintf("/projects/%d/remote_mirrors", projectID)
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	// logrus.Info(fmt.Sprintf("fetchUri: %s", fetchUri))
-	mirrorTemplate := `{
-			"url": "%s",
-			"enabled": "true",
-			"only_protected_branches": "true"
-			}`
-	body := fmt.Sprintf(mirrorTemplate, mirrorURL)
-	// logrus.Info(fmt.Sprintf("body: %s", body))
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		SetBody(body).
-		Post(fetchUri)
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
-		return ProjectMirror{}, resperr
+	body := projectMirrorRequest{
+		URL:                   mirrorURL,
+		Enabled:               true,
+		OnlyProtectedBranches: true,
 	}
-
-	// logrus.Info(fmt.Sprintf("%s", string(resp.Body()[:])))
-
 	var pm ProjectMirror
-	marshErr := json.Unmarshal(resp.Body(), &pm)
-	if marshErr != nil {
-		logrus.Fatal("Cannot marshall Pipeline", marshErr)
-		return ProjectMirror{}, resperr
+	if _, err := r.do(ctx, http.MethodPost, uri, body, &pm); err != nil {
+		return ProjectMirror{}, err
 	}
 
 	return pm, nil
@@ -398,6 +408,11 @@ func (r *gitlabClient) CreateProjectMirror(projectID int, mirrorURL string) (Pro
 //
 // GitLab API docs:
 func (r *gitlabClient) UpdateProjectMirror(projectID int, mirrorID int) (ProjectMirror, error) {
+	return r.UpdateProjectMirrorWithContext(context.Background(), projectID, mirrorID)
+}
+
+// UpdateProjectMirrorWithContext - UpdateProjectMirror bound to ctx
+func (r *gitlabClient) UpdateProjectMirrorWithContext(ctx context.Context, projectID int, mirrorID int) (ProjectMirror, error) {
 
 	// curl -Ls --request PUT https://git.alteryx.com/api/v4/projects/${PR_ID}/remote_mirrors/${m_id} \
 	//             --header "PRIVATE-TOKEN: ${PRIV_TOKEN}" \
@@ -408,27 +423,13 @@ func (r *gitlabClient) UpdateProjectMirror(projectID int, mirrorID int) (Project
 	//             }" | jq -r '([.id,.url,.enabled,.only_protected_branches]) | @csv')
 
 	uri := fmt.Sprintf("/projects/%d/remote_mirrors/%d", projectID, mirrorID)
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	// logrus.Info(fmt.Sprintf("fetchUri: %s", fetchUri))
-	mirrorTemplate := "enabled=true&only_protected_branches=true"
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/x-www-form-urlencoded").
-		SetBody(mirrorTemplate).
-		Put(fetchUri)
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
-		return ProjectMirror{}, resperr
+	body := projectMirrorRequest{
+		Enabled:               true,
+		OnlyProtectedBranches: true,
 	}
-
-	// logrus.Info(fmt.Sprintf("%s", string(resp.Body()[:])))
-
 	var pm ProjectMirror
-	marshErr := json.Unmarshal(resp.Body(), &pm)
-	if marshErr != nil {
-		logrus.Fatal("Cannot marshall Pipeline", marshErr)
-		return ProjectMirror{}, resperr
+	if _, err := r.do(ctx, http.MethodPut, uri, body, &pm); err != nil {
+		return ProjectMirror{}, err
 	}
 
 	return pm, nil
@@ -440,26 +441,17 @@ func (r *gitlabClient) UpdateProjectMirror(projectID int, mirrorID int) (Project
 // GitLab API docs:
 // https://docs.gitlab.com/ee/api/projects.html#get-single-project
 func (r *gitlabClient) GetForcePushSetting(projectID int, protectedBranch string) (bool, error) {
+	return r.GetForcePushSettingWithContext(context.Background(), projectID, protectedBranch)
+}
 
-	// curl -Ls --header "PRIVATE-TOKEN: ${PUB_TOKEN}" "https://gitlab.com/api/v4/projects/${PR_ID}/protected_branches/master" | jq '.allow_force_push'
-	uri := fmt.Sprintf("/projects/%d/protected_branches/%s", projectID, protectedBranch)
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	// fmt.Printf("fetchUri: %s\n", fetchUri)
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		Get(fetchUri)
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
-		return false, resperr
-	}
+// GetForcePushSettingWithContext - GetForcePushSetting bound to ctx
+func (r *gitlabClient) GetForcePushSettingWithContext(ctx context.Context, projectID int, protectedBranch string) (bool, error) {
 
+	// curl -Ls --header "PRIVATE-TOKEN: ${PUB_TOKEN}" "https://gitlab.com/api/v4/projects/${PR_ID}/protected_branches/master" | jq '.allow_force_push'
+	uri := fmt.Sprintf("/projects/%d/protected_branches/%s", projectID, pathEscape(protectedBranch))
 	var pbs ProtectedBranchSettings
-	marshErr := json.Unmarshal(resp.Body(), &pbs)
-	if marshErr != nil {
-		logrus.Fatal("Cannot marshall Pipeline", marshErr)
-		return false, resperr
+	if _, err := r.do(ctx, http.MethodGet, uri, nil, &pbs); err != nil {
+		return false, err
 	}
 
 	return pbs.AllowForcePush, nil
@@ -1,41 +1,43 @@
 package gitlab
 
 import (
+	"context"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
-
-	"github.com/sirupsen/logrus"
+	"net/http"
+	"net/url"
 )
 
-// GetRepositoryFile - returns file contents from a repository file
+// GetRepositoryFile - returns file contents from a repository file.  The
+// project may be an ID or a plain path and the file a plain path
+// (".gitlab-ci.yml", "deploy/values.yaml"), both are escaped by the client.
+// Pass them unescaped: an already escaped "%2Egitlab-ci%2Eyml" is escaped
+// again and not found.
 //
 // GitLab API docs:
 // https://docs.gitlab.com/ee/api/repository_files.html#get-file-from-repository
 func (r *gitlabClient) GetRepositoryFile(projectSlug string, fileSlug string, ref string) ([]byte, error) {
+	return r.GetRepositoryFileWithContext(context.Background(), projectSlug, fileSlug, ref)
+}
+
+// GetRepositoryFileWithContext - GetRepositoryFile bound to ctx
+func (r *gitlabClient) GetRepositoryFileWithContext(ctx context.Context, projectSlug string, fileSlug string, ref string) ([]byte, error) {
 
 	// GET /projects/:id/repository/files/:file_path
 	// curl --header "PRIVATE-TOKEN: ${GITLAB_TOKEN}" \
 	//      --url "https://git.alteryx.com/api/v4/projects/futurama%2Fhermes%2Fcontrol-plane%2Fgcp%2Flowers%2Fc-us-e4-d00101/repository/files/%2Egitlab-ci%2Eyml?ref=master" \
 	//      | jq -r '.content' | base64 -d
 
-	uri := fmt.Sprintf("/projects/%s/repository/files/%s?ref=%s", projectSlug, fileSlug, ref)
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	// fmt.Printf("fetchUri: %s\n", fetchUri)
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		Get(fetchUri)
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
-		return []byte{}, resperr
+	pid, err := pathID(projectSlug)
+	if err != nil {
+		return []byte{}, err
 	}
-
+	query := url.Values{}
+	query.Set("ref", ref)
+	uri := fmt.Sprintf("/projects/%s/repository/files/%s?%s", pid, pathEscape(fileSlug), query.Encode())
 	var rf RepositoryFile
-	marshErr := json.Unmarshal(resp.Body(), &rf)
-	if marshErr != nil {
-		return []byte{}, marshErr
+	if _, err := r.do(ctx, http.MethodGet, uri, nil, &rf); err != nil {
+		return []byte{}, err
 	}
 
 	fileBytes, err := base64.StdEncoding.DecodeString(rf.Content)
@@ -0,0 +1,25 @@
+package gitlab_test
+
+import (
+	"testing"
+
+	gitlab "github.com/maahsome/gitlab-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRepositoryFileNames(t *testing.T) {
+
+	fake, client := newFakeClient(t)
+	g := fake.AddGroup(gitlab.Group{Path: "platform", Name: "Platform"})
+	p := fake.AddProject(g.ID, gitlab.Project{Path: "site", Name: "site"})
+	for _, name := range []string{".gitlab-ci.yml", "deploy/values.yaml", "100%done.txt", "a%2Fb.txt"} {
+		fake.AddFile(p.ID, "main", name, []byte(name))
+	}
+
+	for _, name := range []string{".gitlab-ci.yml", "deploy/values.yaml", "100%done.txt", "a%2Fb.txt"} {
+		content, err := client.GetRepositoryFile("platform/site", name, "main")
+		require.NoError(t, err, name)
+		assert.Equal(t, name, string(content))
+	}
+}
@@ -1,44 +1,294 @@
 package gitlab
 
 import (
+	"context"
 	"fmt"
-	"strings"
-
-	"github.com/sirupsen/logrus"
+	"net/http"
+	"time"
 )
 
-// GetUsers - Return users from gitlab
+// ListUsersOptions filters ListUsers
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/users.html#list-users
+type ListUsersOptions struct {
+	PaginationOptions
+	SortOptions
+	Search        string     `url:"search,omitempty"`
+	Username      string     `url:"username,omitempty"`
+	Active        *bool      `url:"active,omitempty"`
+	Blocked       *bool      `url:"blocked,omitempty"`
+	External      *bool      `url:"external,omitempty"`
+	Admins        *bool      `url:"admins,omitempty"`
+	CreatedAfter  *time.Time `url:"created_after,omitempty"`
+	CreatedBefore *time.Time `url:"created_before,omitempty"`
+}
+
+// ListUserMembershipsOptions filters ListUserMemberships
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/users.html#user-memberships
+type ListUserMembershipsOptions struct {
+	PaginationOptions
+	// Type is "Project" or "Namespace", empty for both
+	Type string `url:"type,omitempty"`
+}
+
+// ListUsers - returns the users matching opts
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/users.html#list-users
+func (r *gitlabClient) ListUsers(opts *ListUsersOptions) (UserList, error) {
+	return r.ListUsersWithContext(context.Background(), opts)
+}
+
+// ListUsersWithContext - ListUsers bound to ctx
+func (r *gitlabClient) ListUsersWithContext(ctx context.Context, opts *ListUsersOptions) (UserList, error) {
+
+	if opts == nil {
+		opts = &ListUsersOptions{}
+	}
+	uri, err := withQuery("/users", opts)
+	if err != nil {
+		return UserList{}, err
+	}
+	users := UserList{}
+	if err := r.listAll(ctx, uri, opts.pager(), &users); err != nil {
+		return UserList{}, err
+	}
+
+	return users, nil
+
+}
+
+// GetUsers - Return active users from gitlab as a JSON array, see ListUsers
+// for typed results
 func (r *gitlabClient) GetUsers(search string) (string, error) {
+	return r.GetUsersWithContext(context.Background(), search)
+}
 
-	nextPage := "1"
-	combinedResults := ""
-	uri := "/users?active=true"
-	for {
-		// TODO: detect if there are no options passed in, ? verus & for page option
-		fetchUri := fmt.Sprintf("https://%s%s%s&search=%s&page=%s", r.BaseUrl, r.ApiPath, uri, search, nextPage)
-		// logrus.Warn(fetchUri)
-		resp, resperr := r.Client.R().
-			SetHeader("PRIVATE-TOKEN", r.Token).
-			Get(fetchUri)
-
-		if resperr != nil {
-			logrus.WithError(resperr).Error("Oops")
-			return "", resperr
-		}
+// GetUsersWithContext - GetUsers bound to ctx
+func (r *gitlabClient) GetUsersWithContext(ctx context.Context, search string) (string, error) {
 
-		items := strings.TrimPrefix(string(resp.Body()[:]), "[")
-		items = strings.TrimSuffix(items, "]")
-		if combinedResults == "" {
-			combinedResults += items
-		} else {
-			combinedResults += fmt.Sprintf(", %s", items)
-		}
-		currentPage := resp.Header().Get("X-Page")
-		nextPage = resp.Header().Get("X-Next-Page")
-		totalPages := resp.Header().Get("X-Total-Pages")
-		if currentPage == totalPages {
-			break
+	opts := &ListUsersOptions{
+		Search: search,
+		Active: Bool(true),
+	}
+	uri, err := withQuery("/users", opts)
+	if err != nil {
+		return "", err
+	}
+	return r.listRaw(ctx, uri, opts.pager())
+}
+
+// GetCurrentUser - the user owning the client's credentials
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/users.html#list-current-user
+func (r *gitlabClient) GetCurrentUser() (User, error) {
+	return r.GetCurrentUserWithContext(context.Background())
+}
+
+// GetCurrentUserWithContext - GetCurrentUser bound to ctx
+func (r *gitlabClient) GetCurrentUserWithContext(ctx context.Context) (User, error) {
+
+	var user User
+	if _, err := r.do(ctx, http.MethodGet, "/user", nil, &user); err != nil {
+		return User{}, err
+	}
+
+	return user, nil
+
+}
+
+// GetUser - a single user by ID
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/users.html#single-user
+func (r *gitlabClient) GetUser(userID int) (User, error) {
+	return r.GetUserWithContext(context.Background(), userID)
+}
+
+// GetUserWithContext - GetUser bound to ctx
+func (r *gitlabClient) GetUserWithContext(ctx context.Context, userID int) (User, error) {
+
+	uri := fmt.Sprintf("/users/%d", userID)
+	var user User
+	if _, err := r.do(ctx, http.MethodGet, uri, nil, &user); err != nil {
+		return User{}, err
+	}
+
+	return user, nil
+
+}
+
+// GetUserByUsername - a single user by username, a *RequestError with
+// status 404 when there is no such user
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/users.html#list-users
+func (r *gitlabClient) GetUserByUsername(username string) (User, error) {
+	return r.GetUserByUsernameWithContext(context.Background(), username)
+}
+
+// GetUserByUsernameWithContext - GetUserByUsername bound to ctx
+func (r *gitlabClient) GetUserByUsernameWithContext(ctx context.Context, username string) (User, error) {
+
+	users, err := r.ListUsersWithContext(ctx, &ListUsersOptions{Username: username})
+	if err != nil {
+		return User{}, err
+	}
+	if len(users) == 0 {
+		return User{}, &RequestError{
+			StatusCode: http.StatusNotFound,
+			Method:     http.MethodGet,
+			URL:        r.endpoint("/users"),
+			Message:    fmt.Sprintf("404 User %s Not Found", username),
 		}
 	}
-	return fmt.Sprintf("[%s]", combinedResults), nil
+
+	return users[0], nil
+
+}
+
+// ListUserSSHKeys - the SSH keys of a user
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/users.html#list-ssh-keys-for-user
+func (r *gitlabClient) ListUserSSHKeys(userID int, opts *PaginationOptions) (SSHKeys, error) {
+	return r.ListUserSSHKeysWithContext(context.Background(), userID, opts)
+}
+
+// ListUserSSHKeysWithContext - ListUserSSHKeys bound to ctx
+func (r *gitlabClient) ListUserSSHKeysWithContext(ctx context.Context, userID int, opts *PaginationOptions) (SSHKeys, error) {
+
+	if opts == nil {
+		opts = &PaginationOptions{}
+	}
+	uri, err := withQuery(fmt.Sprintf("/users/%d/keys", userID), opts)
+	if err != nil {
+		return SSHKeys{}, err
+	}
+	keys := SSHKeys{}
+	if err := r.listAll(ctx, uri, opts.pager(), &keys); err != nil {
+		return SSHKeys{}, err
+	}
+
+	return keys, nil
+
+}
+
+// ListUserGPGKeys - the GPG keys of a user
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/users.html#list-all-gpg-keys-for-given-user
+func (r *gitlabClient) ListUserGPGKeys(userID int, opts *PaginationOptions) (GPGKeys, error) {
+	return r.ListUserGPGKeysWithContext(context.Background(), userID, opts)
+}
+
+// ListUserGPGKeysWithContext - ListUserGPGKeys bound to ctx
+func (r *gitlabClient) ListUserGPGKeysWithContext(ctx context.Context, userID int, opts *PaginationOptions) (GPGKeys, error) {
+
+	if opts == nil {
+		opts = &PaginationOptions{}
+	}
+	uri, err := withQuery(fmt.Sprintf("/users/%d/gpg_keys", userID), opts)
+	if err != nil {
+		return GPGKeys{}, err
+	}
+	keys := GPGKeys{}
+	if err := r.listAll(ctx, uri, opts.pager(), &keys); err != nil {
+		return GPGKeys{}, err
+	}
+
+	return keys, nil
+
+}
+
+// ListUserMemberships - the groups and projects a user is a direct member
+// of, administrators only
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/users.html#user-memberships
+func (r *gitlabClient) ListUserMemberships(userID int, opts *ListUserMembershipsOptions) (UserMemberships, error) {
+	return r.ListUserMembershipsWithContext(context.Background(), userID, opts)
+}
+
+// ListUserMembershipsWithContext - ListUserMemberships bound to ctx
+func (r *gitlabClient) ListUserMembershipsWithContext(ctx context.Context, userID int, opts *ListUserMembershipsOptions) (UserMemberships, error) {
+
+	if opts == nil {
+		opts = &ListUserMembershipsOptions{}
+	}
+	uri, err := withQuery(fmt.Sprintf("/users/%d/memberships", userID), opts)
+	if err != nil {
+		return UserMemberships{}, err
+	}
+	memberships := UserMemberships{}
+	if err := r.listAll(ctx, uri, opts.pager(), &memberships); err != nil {
+		return UserMemberships{}, err
+	}
+
+	return memberships, nil
+
+}
+
+// BlockUser - block a user, administrators only
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/users.html#block-user
+func (r *gitlabClient) BlockUser(userID int) error {
+	return r.BlockUserWithContext(context.Background(), userID)
+}
+
+// BlockUserWithContext - BlockUser bound to ctx
+func (r *gitlabClient) BlockUserWithContext(ctx context.Context, userID int) error {
+	return r.userAction(ctx, userID, "block")
+}
+
+// UnblockUser - unblock a blocked user, administrators only
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/users.html#unblock-user
+func (r *gitlabClient) UnblockUser(userID int) error {
+	return r.UnblockUserWithContext(context.Background(), userID)
+}
+
+// UnblockUserWithContext - UnblockUser bound to ctx
+func (r *gitlabClient) UnblockUserWithContext(ctx context.Context, userID int) error {
+	return r.userAction(ctx, userID, "unblock")
+}
+
+// DeactivateUser - deactivate a dormant user, administrators only
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/users.html#deactivate-user
+func (r *gitlabClient) DeactivateUser(userID int) error {
+	return r.DeactivateUserWithContext(context.Background(), userID)
+}
+
+// DeactivateUserWithContext - DeactivateUser bound to ctx
+func (r *gitlabClient) DeactivateUserWithContext(ctx context.Context, userID int) error {
+	return r.userAction(ctx, userID, "deactivate")
+}
+
+// ActivateUser - reactivate a deactivated user, administrators only
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/users.html#activate-user
+func (r *gitlabClient) ActivateUser(userID int) error {
+	return r.ActivateUserWithContext(context.Background(), userID)
+}
+
+// ActivateUserWithContext - ActivateUser bound to ctx
+func (r *gitlabClient) ActivateUserWithContext(ctx context.Context, userID int) error {
+	return r.userAction(ctx, userID, "activate")
+}
+
+// userAction POSTs to one of the /users/:id/<action> admin endpoints
+func (r *gitlabClient) userAction(ctx context.Context, userID int, action string) error {
+
+	uri := fmt.Sprintf("/users/%d/%s", userID, action)
+	_, err := r.do(ctx, http.MethodPost, uri, nil, nil)
+	return err
 }
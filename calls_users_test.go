@@ -0,0 +1,213 @@
+package gitlab_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	gitlab "github.com/maahsome/gitlab-go"
+	"github.com/maahsome/gitlab-go/gitlabtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestListUsersQuery(t *testing.T) {
+
+	fake := gitlabtest.NewServer()
+	t.Cleanup(fake.Close)
+	var queries []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		fake.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	client, err := gitlab.NewClient(
+		gitlab.WithBaseURL(srv.URL),
+		gitlab.WithToken(gitlabtest.DefaultToken),
+		gitlab.WithLogger(gitlab.NopLogger()),
+	)
+	require.NoError(t, err)
+
+	old := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	recent := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	fake.AddUserDetails(gitlab.User{Username: "alice", Name: "Alice", IsAdmin: true, CreatedAt: &old})
+	fake.AddUserDetails(gitlab.User{Username: "bob", Name: "Bob", State: "blocked", CreatedAt: &recent})
+	fake.AddUserDetails(gitlab.User{Username: "carol", Name: "Carol", External: true, CreatedAt: &recent})
+
+	after := time.Date(2022, 3, 4, 5, 6, 7, 0, time.FixedZone("CET", 3600))
+	for _, tc := range []struct {
+		name  string
+		opts  gitlab.ListUsersOptions
+		query url.Values
+		users []string
+	}{
+		{"blocked", gitlab.ListUsersOptions{Blocked: gitlab.Bool(true)}, url.Values{"blocked": {"true"}}, []string{"bob"}},
+		{"external", gitlab.ListUsersOptions{External: gitlab.Bool(true)}, url.Values{"external": {"true"}}, []string{"carol"}},
+		{"not external", gitlab.ListUsersOptions{External: gitlab.Bool(false)}, url.Values{"external": {"false"}}, []string{"alice", "bob"}},
+		{"admins", gitlab.ListUsersOptions{Admins: gitlab.Bool(true)}, url.Values{"admins": {"true"}}, []string{"alice"}},
+		{"created after", gitlab.ListUsersOptions{CreatedAfter: &after}, url.Values{"created_after": {"2022-03-04T05:06:07+01:00"}}, []string{"bob", "carol"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			queries = nil
+			users, err := client.Users().ListUsers(&tc.opts)
+			require.NoError(t, err)
+			require.Len(t, queries, 1)
+			for key, want := range tc.query {
+				assert.Equal(t, want, queries[0][key], key)
+			}
+			if v := queries[0].Get("created_after"); v != "" {
+				_, err := time.Parse(time.RFC3339, v)
+				assert.NoError(t, err, "created_after is RFC3339")
+			}
+			names := []string{}
+			for _, u := range users {
+				names = append(names, u.Username)
+			}
+			assert.Equal(t, tc.users, names)
+		})
+	}
+}
+
+func TestGetUserByUsername(t *testing.T) {
+
+	fake, client := newFakeClient(t)
+	alice := fake.AddUser("alice", "Alice")
+	fake.AddUser("alicia", "Alicia")
+
+	u, err := client.Users().GetUserByUsername("alice")
+	require.NoError(t, err)
+	assert.Equal(t, alice, u.ID)
+	assert.Equal(t, "Alice", u.Name)
+
+	_, err = client.Users().GetUserByUsername("mallory")
+	require.Error(t, err)
+	assert.True(t, gitlab.IsNotFound(err))
+	var reqErr *gitlab.RequestError
+	require.True(t, errors.As(err, &reqErr))
+	assert.Equal(t, http.StatusNotFound, reqErr.StatusCode)
+	assert.Equal(t, http.MethodGet, reqErr.Method)
+	assert.Contains(t, reqErr.Message, "mallory")
+}
+
+func TestListUserKeys(t *testing.T) {
+
+	fake, client := newFakeClient(t)
+	alice := fake.AddUser("alice", "Alice")
+	bob := fake.AddUser("bob", "Bob")
+	ssh := fake.AddSSHKey(alice, gitlab.SSHKey{Title: "laptop", Key: "ssh-ed25519 AAAA alice@laptop"})
+	fake.AddSSHKey(bob, gitlab.SSHKey{Title: "desktop", Key: "ssh-ed25519 BBBB bob@desktop"})
+	gpg := fake.AddGPGKey(alice, gitlab.GPGKey{Key: "-----BEGIN PGP PUBLIC KEY BLOCK-----"})
+
+	sshKeys, err := client.Users().ListUserSSHKeys(alice, nil)
+	require.NoError(t, err)
+	require.Len(t, sshKeys, 1)
+	assert.Equal(t, ssh.ID, sshKeys[0].ID)
+	assert.Equal(t, "laptop", sshKeys[0].Title)
+	assert.Equal(t, ssh.Key, sshKeys[0].Key)
+
+	gpgKeys, err := client.Users().ListUserGPGKeys(alice, nil)
+	require.NoError(t, err)
+	require.Len(t, gpgKeys, 1)
+	assert.Equal(t, gpg.ID, gpgKeys[0].ID)
+	assert.Equal(t, gpg.Key, gpgKeys[0].Key)
+
+	gpgKeys, err = client.Users().ListUserGPGKeys(bob, nil)
+	require.NoError(t, err)
+	assert.Empty(t, gpgKeys)
+
+	_, err = client.Users().ListUserSSHKeys(999, nil)
+	assert.True(t, gitlab.IsNotFound(err))
+}
+
+func TestListUserMemberships(t *testing.T) {
+
+	fake, client := newFakeClient(t)
+	alice := fake.AddUser("alice", "Alice")
+	g := fake.AddGroup(gitlab.Group{Path: "platform", Name: "Platform"})
+	p := fake.AddProject(g.ID, gitlab.Project{Path: "site", Name: "Site"})
+	fake.AddMember("groups", g.ID, alice, int(gitlab.DeveloperAccess))
+	fake.AddMember("projects", p.ID, alice, int(gitlab.MaintainerAccess))
+
+	memberships, err := client.Users().ListUserMemberships(alice, nil)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, gitlab.UserMemberships{
+		{SourceID: g.ID, SourceName: "Platform", SourceType: "Namespace", AccessLevel: gitlab.DeveloperAccess},
+		{SourceID: p.ID, SourceName: "Site", SourceType: "Project", AccessLevel: gitlab.MaintainerAccess},
+	}, memberships)
+
+	memberships, err = client.Users().ListUserMemberships(alice, &gitlab.ListUserMembershipsOptions{Type: "Project"})
+	require.NoError(t, err)
+	require.Len(t, memberships, 1)
+	assert.Equal(t, p.ID, memberships[0].SourceID)
+
+	memberships, err = client.Users().ListUserMemberships(alice, &gitlab.ListUserMembershipsOptions{Type: "Namespace"})
+	require.NoError(t, err)
+	require.Len(t, memberships, 1)
+	assert.Equal(t, g.ID, memberships[0].SourceID)
+
+	_, err = client.Users().ListUserMemberships(alice, &gitlab.ListUserMembershipsOptions{Type: "Group"})
+	var reqErr *gitlab.RequestError
+	require.True(t, errors.As(err, &reqErr))
+	assert.Equal(t, http.StatusBadRequest, reqErr.StatusCode)
+}
+
+func TestUserOutput(t *testing.T) {
+
+	created := time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+	alice := gitlab.User{ID: 7, Username: "alice", Name: "Alice Liddell", State: "active", CreatedAt: &created}
+	bob := gitlab.User{ID: 8, Username: "bob", Name: "Bob", State: "blocked"}
+	users := gitlab.UserList{alice, bob}
+
+	var decoded gitlab.User
+	require.NoError(t, json.Unmarshal([]byte(alice.ToJSON()), &decoded))
+	assert.Equal(t, alice, decoded)
+	var decodedList gitlab.UserList
+	require.NoError(t, json.Unmarshal([]byte(users.ToJSON()), &decodedList))
+	assert.Equal(t, users, decodedList)
+
+	decoded = gitlab.User{}
+	require.NoError(t, yaml.Unmarshal([]byte(alice.ToYAML()), &decoded))
+	assert.Equal(t, "alice", decoded.Username)
+	assert.Equal(t, "Alice Liddell", decoded.Name)
+	decodedList = nil
+	require.NoError(t, yaml.Unmarshal([]byte(users.ToYAML()), &decodedList))
+	require.Len(t, decodedList, 2)
+	assert.Equal(t, "blocked", decodedList[1].State)
+
+	gron := alice.ToGRON()
+	assert.Contains(t, gron, "json.username")
+	assert.Contains(t, gron, `"alice"`)
+	gron = users.ToGRON()
+	assert.Contains(t, gron, "json[1].username")
+	assert.Contains(t, gron, `"bob"`)
+
+	assert.Equal(t, [][]string{
+		{"ID", "USERNAME", "NAME", "STATE"},
+		{"7", "alice", "Alice Liddell", "active"},
+		{"8", "bob", "Bob", "blocked"},
+	}, textRows(users.ToTEXT(false)))
+	assert.Equal(t, [][]string{
+		{"7", "alice", "Alice Liddell", "active"},
+	}, textRows(alice.ToTEXT(true)))
+}
+
+// textRows splits ToTEXT output into its tab separated, padded cells
+func textRows(text string) [][]string {
+	rows := [][]string{}
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		row := []string{}
+		for _, cell := range strings.Split(line, "\t") {
+			if cell = strings.TrimSpace(cell); cell != "" {
+				row = append(row, cell)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
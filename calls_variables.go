@@ -1,76 +1,39 @@
 package gitlab
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"strings"
-
-	"github.com/sirupsen/logrus"
+	"net/http"
 )
 
 func (r *gitlabClient) GetVariableFrom(id int, resource string, variable string) (string, error) {
+	return r.GetVariableFromWithContext(context.Background(), id, resource, variable)
+}
 
-	uri := fmt.Sprintf("/%s/%d/variables/%s", resource, id, variable)
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		Get(fetchUri)
+// GetVariableFromWithContext - GetVariableFrom bound to ctx
+func (r *gitlabClient) GetVariableFromWithContext(ctx context.Context, id int, resource string, variable string) (string, error) {
 
+	uri := fmt.Sprintf("/%s/%d/variables/%s", resource, id, variable)
+	resp, resperr := r.do(ctx, http.MethodGet, uri, nil, nil)
 	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
 		return "", resperr
 	}
 
 	return string(resp.Body()[:]), nil
 }
 
-func getVariablesFrom(r *gitlabClient, id int, resource string) (Variables, error) {
+func getVariablesFrom(ctx context.Context, r *gitlabClient, id int, resource string) (Variables, error) {
 
-	nextPage := "1"
-	combinedResults := ""
-
-	for {
-		uri := fmt.Sprintf("/%s/%d/variables", resource, id)
-		fetchUri := fmt.Sprintf("https://%s%s%s?page=%s", r.BaseUrl, r.ApiPath, uri, nextPage)
-		resp, resperr := r.Client.R().
-			SetHeader("PRIVATE-TOKEN", r.Token).
-			SetHeader("Content-Type", "application/json").
-			Get(fetchUri)
-
-		if resperr != nil {
-			logrus.WithError(resperr).Error("Oops")
-			return Variables{}, resperr
-		}
-
-		items := strings.TrimPrefix(string(resp.Body()[:]), "[")
-		items = strings.TrimSuffix(items, "]")
-		if combinedResults == "" {
-			combinedResults += items
-		} else {
-			combinedResults += fmt.Sprintf(", %s", items)
-		}
-		currentPage := resp.Header().Get("X-Page")
-		nextPage = resp.Header().Get("X-Next-Page")
-		totalPages := resp.Header().Get("X-Total-Pages")
-		if currentPage == totalPages {
-			break
-		}
-
-	}
-	surroundArray := fmt.Sprintf("[%s]", combinedResults)
-	var variables Variables
-	marshErr := json.Unmarshal([]byte(surroundArray), &variables)
-	if marshErr != nil {
-		logrus.Fatal("Cannot marshall Pipeline", marshErr)
-		return Variables{}, marshErr
+	uri := fmt.Sprintf("/%s/%d/variables", resource, id)
+	variables := Variables{}
+	if err := r.listAll(ctx, uri, nil, &variables); err != nil {
+		return Variables{}, err
 	}
 
 	if resource == "projects" {
-		projectInfo, perr := r.GetProject(id)
+		projectInfo, perr := r.GetProjectWithContext(ctx, id)
 		if perr != nil {
-			logrus.Error("Cannot marshall Pipeline", marshErr)
-			return variables, perr
+			return variables, fmt.Errorf("resolving source for project %d variables: %w", id, perr)
 		}
 		// for _, v := range variables {
 		for k := range variables {
@@ -80,10 +43,9 @@ func getVariablesFrom(r *gitlabClient, id int, resource string) (Variables, erro
 	}
 
 	if resource == "groups" {
-		groupInfo, gerr := r.GetGroup(id)
+		groupInfo, gerr := r.GetGroupWithContext(ctx, id)
 		if gerr != nil {
-			logrus.Error("Cannot marshall Pipeline", marshErr)
-			return variables, gerr
+			return variables, fmt.Errorf("resolving source for group %d variables: %w", id, gerr)
 		}
 		for k := range variables {
 			// variables[k].Source = groupInfo.Path
@@ -99,6 +61,11 @@ func getVariablesFrom(r *gitlabClient, id int, resource string) (Variables, erro
 // https://docs.gitlab.com/ee/api/project_level_variables.html
 // https://docs.gitlab.com/ee/api/group_level_variables.html
 func (r *gitlabClient) GetCicdVariables(projectID int) (Variables, error) {
+	return r.GetCicdVariablesWithContext(context.Background(), projectID)
+}
+
+// GetCicdVariablesWithContext - GetCicdVariables bound to ctx
+func (r *gitlabClient) GetCicdVariablesWithContext(ctx context.Context, projectID int) (Variables, error) {
 
 	// curl -Ls --header "PRIVATE-TOKEN: ${GITLAB_TOKEN}" "https://git.alteryx.com/api/v4/projects/5844/variables" | jq .
 
@@ -106,28 +73,14 @@ func (r *gitlabClient) GetCicdVariables(projectID int) (Variables, error) {
 	// Fetch the GroupID, extract .parent_id until 'null'
 
 	uri := fmt.Sprintf("/projects/%d/variables", projectID)
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		Get(fetchUri)
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
-		return Variables{}, resperr
-	}
-
-	var variables Variables
-	marshErr := json.Unmarshal(resp.Body(), &variables)
-	if marshErr != nil {
-		logrus.Fatal("Cannot marshall Pipeline", marshErr)
-		return Variables{}, resperr
+	variables := Variables{}
+	if err := r.listAll(ctx, uri, nil, &variables); err != nil {
+		return Variables{}, err
 	}
 
-	projectInfo, perr := r.GetProject(projectID)
+	projectInfo, perr := r.GetProjectWithContext(ctx, projectID)
 	if perr != nil {
-		logrus.Error("Cannot marshall Pipeline", marshErr)
-		return variables, perr
+		return variables, fmt.Errorf("resolving source for project %d variables: %w", projectID, perr)
 	}
 
 	for k := range variables {
@@ -137,16 +90,14 @@ func (r *gitlabClient) GetCicdVariables(projectID int) (Variables, error) {
 	if projectInfo.Namespace.ID > 0 {
 		groupID := projectInfo.Namespace.ID
 		for {
-			parentVariables, verr := getVariablesFrom(r, groupID, "groups")
+			parentVariables, verr := getVariablesFrom(ctx, r, groupID, "groups")
 			if verr != nil {
-				logrus.Error("Cannot marshall Pipeline", marshErr)
-				return variables, resperr
+				return variables, fmt.Errorf("reading variables of ancestor group %d: %w", groupID, verr)
 			}
 			variables = append(variables, parentVariables...)
-			groupInfo, gerr := r.GetGroup(groupID)
+			groupInfo, gerr := r.GetGroupWithContext(ctx, groupID)
 			if gerr != nil {
-				logrus.Error("Cannot marshall Pipeline", marshErr)
-				return variables, gerr
+				return variables, fmt.Errorf("reading ancestor group %d: %w", groupID, gerr)
 			}
 			if groupInfo.ParentID == 0 {
 				break
@@ -165,6 +116,12 @@ func (r *gitlabClient) GetCicdVariables(projectID int) (Variables, error) {
 // https://docs.gitlab.com/ee/api/project_level_variables.html
 // https://docs.gitlab.com/ee/api/group_level_variables.html
 func (r *gitlabClient) GetCicdVariablesFromGroup(groupID int, includeProjects bool) (Variables, error) {
+	return r.GetCicdVariablesFromGroupWithContext(context.Background(), groupID, includeProjects)
+}
+
+// GetCicdVariablesFromGroupWithContext - GetCicdVariablesFromGroup bound to
+// ctx, cancelling ctx stops the walk between and during requests
+func (r *gitlabClient) GetCicdVariablesFromGroupWithContext(ctx context.Context, groupID int, includeProjects bool) (Variables, error) {
 
 	// curl -Ls --header "PRIVATE-TOKEN: ${GITLAB_TOKEN}" "https://git.alteryx.com/api/v4/projects/5844/variables" | jq .
 
@@ -172,51 +129,44 @@ func (r *gitlabClient) GetCicdVariablesFromGroup(groupID int, includeProjects bo
 	// Fetch the GroupID, extract .parent_id until 'null'
 
 	var variables Variables
-	topVariables, verr := getVariablesFrom(r, groupID, "groups")
+	topVariables, verr := getVariablesFrom(ctx, r, groupID, "groups")
 	if verr != nil {
-		logrus.Error("Cannot marshall Pipeline", verr)
-		return variables, verr
+		return variables, fmt.Errorf("reading variables of group %d: %w", groupID, verr)
 	}
 	variables = append(variables, topVariables...)
 
 	if includeProjects {
-		topProjects, perr := r.GetGroupProjects(groupID)
+		topProjects, perr := r.GetGroupProjectsWithContext(ctx, groupID)
 		if perr != nil {
-			logrus.Error("Failed to get top level group Projects", perr)
-			return variables, perr
+			return variables, fmt.Errorf("listing projects of group %d: %w", groupID, perr)
 		}
 		for _, v := range topProjects {
-			projVariables, verr := getVariablesFrom(r, v.ID, "projects")
+			projVariables, verr := getVariablesFrom(ctx, r, v.ID, "projects")
 			if verr != nil {
-				logrus.Error("Failed to get variables from topProjects ", verr)
-				return variables, verr
+				return variables, fmt.Errorf("reading variables of project %d: %w", v.ID, verr)
 			}
 			variables = append(variables, projVariables...)
 		}
 	}
-	subGroups, gerr := r.GetDescendantGroups(groupID)
+	subGroups, gerr := r.GetDescendantGroupsWithContext(ctx, groupID)
 	if gerr != nil {
-		logrus.Error("Failed to get Top Project SubGroups", gerr)
-		return variables, gerr
+		return variables, fmt.Errorf("listing descendant groups of group %d: %w", groupID, gerr)
 	}
 	for _, v := range subGroups {
-		grpVariables, verr := getVariablesFrom(r, v.ID, "groups")
+		grpVariables, verr := getVariablesFrom(ctx, r, v.ID, "groups")
 		if verr != nil {
-			logrus.Error("Failed to get variables from subGroups ", verr)
-			return variables, verr
+			return variables, fmt.Errorf("reading variables of group %d: %w", v.ID, verr)
 		}
 		variables = append(variables, grpVariables...)
 		if includeProjects {
-			grpProjects, perr := r.GetGroupProjects(v.ID)
+			grpProjects, perr := r.GetGroupProjectsWithContext(ctx, v.ID)
 			if perr != nil {
-				logrus.Error("Failed to get Projects for SubGroup ", perr)
-				return variables, perr
+				return variables, fmt.Errorf("listing projects of group %d: %w", v.ID, perr)
 			}
 			for _, p := range grpProjects {
-				projVariables, verr := getVariablesFrom(r, p.ID, "projects")
+				projVariables, verr := getVariablesFrom(ctx, r, p.ID, "projects")
 				if verr != nil {
-					logrus.Error("Failed to get variables from topProjects ", verr)
-					return variables, verr
+					return variables, fmt.Errorf("reading variables of project %d: %w", p.ID, verr)
 				}
 				variables = append(variables, projVariables...)
 			}
@@ -229,22 +179,16 @@ func (r *gitlabClient) GetCicdVariablesFromGroup(groupID int, includeProjects bo
 }
 
 func (r *gitlabClient) UpdateVariableFrom(id int, resource string, variable string, value string) (string, error) {
+	return r.UpdateVariableFromWithContext(context.Background(), id, resource, variable, value)
+}
 
-	escapedValue := strings.ReplaceAll(value, "\"", "\\\"")
-	uri := fmt.Sprintf("/%s/%d/variables/%s", resource, id, variable)
-	fetchUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	variableTemplate := `{
-			"value": "%s"
-			}`
-	body := fmt.Sprintf(variableTemplate, escapedValue)
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		SetHeader("Content-Type", "application/json").
-		SetBody(body).
-		Put(fetchUri)
+// UpdateVariableFromWithContext - UpdateVariableFrom bound to ctx
+func (r *gitlabClient) UpdateVariableFromWithContext(ctx context.Context, id int, resource string, variable string, value string) (string, error) {
 
+	uri := fmt.Sprintf("/%s/%d/variables/%s", resource, id, variable)
+	body := updateVariableRequest{Value: value}
+	resp, resperr := r.do(ctx, http.MethodPut, uri, body, nil)
 	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
 		return "", resperr
 	}
 
@@ -0,0 +1,310 @@
+package gitlab
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// CassetteMode selects whether WithCassette records or replays traffic
+type CassetteMode int
+
+const (
+	// CassetteRecord sends requests to GitLab and appends every exchange to
+	// the cassette file
+	CassetteRecord CassetteMode = iota
+	// CassetteReplay answers requests from the cassette file without network
+	// access, failing any request that was not recorded
+	CassetteReplay
+)
+
+// scrubbed replaces credentials in recorded cassettes
+const scrubbed = "[REDACTED]"
+
+// sensitiveHeaders are scrubbed from recorded requests and responses
+var sensitiveHeaders = []string{"PRIVATE-TOKEN", "JOB-TOKEN", "Authorization", "Cookie", "Set-Cookie"}
+
+// secretFields matches OAuth token fields in JSON bodies, and token fields
+// in form encoded bodies and URLs, including those in Link headers
+var secretFields = regexp.MustCompile(`("(?:access_token|refresh_token|id_token|client_secret)"\s*:\s*")[^"]*(")|((?:^|[?&])(?:access_token|refresh_token|client_secret|code|private_token|key)=)[^&#\s"'<>]*()`)
+
+// embeddedURLs matches URLs inside a body, e.g. a mirror's url, whose
+// passwords redactURLPassword removes
+var embeddedURLs = regexp.MustCompile(`[A-Za-z][A-Za-z0-9+.-]*://[^\s"'<>]+`)
+
+// Cassette is the recorded traffic stored by WithCassette
+type Cassette struct {
+	Interactions []Interaction `yaml:"interactions"`
+}
+
+// Interaction is a single recorded request and its response
+type Interaction struct {
+	Request  CassetteRequest  `yaml:"request"`
+	Response CassetteResponse `yaml:"response"`
+}
+
+// CassetteRequest is a recorded request with its credentials scrubbed
+type CassetteRequest struct {
+	Method  string      `yaml:"method"`
+	URL     string      `yaml:"url"`
+	Headers http.Header `yaml:"headers,omitempty"`
+	Body    string      `yaml:"body,omitempty"`
+}
+
+// CassetteResponse is the response recorded for a CassetteRequest
+type CassetteResponse struct {
+	StatusCode int         `yaml:"status_code"`
+	Headers    http.Header `yaml:"headers,omitempty"`
+	Body       string      `yaml:"body,omitempty"`
+}
+
+// CassetteMissError is returned in replay mode for a request that matches
+// no unused recorded interaction.  It is never retried.
+type CassetteMissError struct {
+	Method string
+	URL    string
+}
+
+func (e *CassetteMissError) Error() string {
+	return fmt.Sprintf("cassette: no recorded interaction for %s %s", e.Method, e.URL)
+}
+
+// LoadCassette reads a cassette file
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette %s: %w", path, err)
+	}
+	var c Cassette
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("parsing cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to path
+func (c *Cassette) Save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// WithCassette records the client's HTTP traffic to, or replays it from,
+// the YAML cassette at path.  Credentials in headers, URLs and bodies are
+// scrubbed before anything is written.  Replay matches method, path, query
+// and body, uses every interaction at most once and fails unmatched
+// requests with a *CassetteMissError.
+func WithCassette(path string, mode CassetteMode) ClientOption {
+	return func(c *clientConfig) error {
+		switch mode {
+		case CassetteRecord:
+			c.transports = append(c.transports, func(next http.RoundTripper) http.RoundTripper {
+				return &recordingTransport{next: next, path: path}
+			})
+		case CassetteReplay:
+			cassette, err := LoadCassette(path)
+			if err != nil {
+				return err
+			}
+			c.transports = append(c.transports, func(next http.RoundTripper) http.RoundTripper {
+				return &replayTransport{cassette: cassette, used: make([]bool, len(cassette.Interactions))}
+			})
+		default:
+			return fmt.Errorf("unknown cassette mode %d", mode)
+		}
+		return nil
+	}
+}
+
+// recordingTransport appends each exchange to the cassette at path
+type recordingTransport struct {
+	next     http.RoundTripper
+	path     string
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	reqBody, err := drainRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	secrets := headerSecrets(req.Header)
+	interaction := Interaction{
+		Request: CassetteRequest{
+			Method:  req.Method,
+			URL:     scrubString(req.URL.String(), secrets),
+			Headers: scrubHeaders(req.Header, secrets),
+			Body:    scrubString(string(reqBody), secrets),
+		},
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    scrubHeaders(resp.Header, secrets),
+			Body:       scrubString(string(respBody), secrets),
+		},
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cassette.Interactions = append(t.cassette.Interactions, interaction)
+	if err := t.cassette.Save(t.path); err != nil {
+		return nil, fmt.Errorf("writing cassette %s: %w", t.path, err)
+	}
+	return resp, nil
+}
+
+// replayTransport serves responses from a cassette
+type replayTransport struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	reqBody, err := drainRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	secrets := headerSecrets(req.Header)
+	body := scrubString(string(reqBody), secrets)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, interaction := range t.cassette.Interactions {
+		if t.used[i] || !interaction.matches(req, body, secrets) {
+			continue
+		}
+		t.used[i] = true
+
+		recorded := interaction.Response
+		header := http.Header{}
+		for k, v := range recorded.Headers {
+			header[k] = append([]string(nil), v...)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+			StatusCode:    recorded.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+			ContentLength: int64(len(recorded.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, &CassetteMissError{Method: req.Method, URL: req.URL.String()}
+}
+
+// matches compares method, path, query and body, ignoring the host so a
+// cassette recorded against one server replays against another
+func (i Interaction) matches(req *http.Request, body string, secrets []string) bool {
+
+	if i.Request.Method != req.Method || i.Request.Body != body {
+		return false
+	}
+	recorded, err := url.Parse(i.Request.URL)
+	if err != nil {
+		return false
+	}
+	current, err := url.Parse(scrubString(req.URL.String(), secrets))
+	if err != nil {
+		return false
+	}
+	return recorded.EscapedPath() == current.EscapedPath() &&
+		reflect.DeepEqual(recorded.Query(), current.Query())
+}
+
+// drainRequestBody reads the request body and puts it back for sending
+func drainRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// headerSecrets returns the credential values sent with a request, the
+// bearer token without its "Bearer " prefix
+func headerSecrets(header http.Header) []string {
+	var secrets []string
+	for _, name := range sensitiveHeaders {
+		for _, v := range header.Values(name) {
+			v = strings.TrimSpace(strings.TrimPrefix(v, "Bearer "))
+			if v != "" {
+				secrets = append(secrets, v)
+			}
+		}
+	}
+	return secrets
+}
+
+// scrubHeaders blanks the sensitive headers and scrubs the others, e.g.
+// Link headers repeating the request's query string
+func scrubHeaders(header http.Header, secrets []string) http.Header {
+	out := http.Header{}
+	for k, v := range header {
+		for _, value := range v {
+			out[k] = append(out[k], scrubString(value, secrets))
+		}
+	}
+	for _, name := range sensitiveHeaders {
+		if _, ok := out[http.CanonicalHeaderKey(name)]; ok {
+			out.Set(name, scrubbed)
+		}
+	}
+	return out
+}
+
+// scrubString replaces every secret in s, as is and URL encoded, the values
+// of OAuth token fields and the passwords of URLs
+func scrubString(s string, secrets []string) string {
+	s = secretFields.ReplaceAllString(s, "${1}${3}"+scrubbed+"${2}${4}")
+	s = embeddedURLs.ReplaceAllStringFunc(s, redactURLPassword)
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, scrubbed)
+		if escaped := url.QueryEscape(secret); escaped != secret {
+			s = strings.ReplaceAll(s, escaped, scrubbed)
+		}
+	}
+	return s
+}
+
+// isCassetteMiss reports whether err comes from an unrecorded request
+func isCassetteMiss(err error) bool {
+	var miss *CassetteMissError
+	return errors.As(err, &miss)
+}
@@ -0,0 +1,264 @@
+package gitlab_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	gitlab "github.com/maahsome/gitlab-go"
+	"github.com/maahsome/gitlab-go/gitlabtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenServer is an OAuth2 token endpoint handing out access, rotating the
+// refresh token, and counting the refreshes
+type tokenServer struct {
+	access string
+
+	mu        sync.Mutex
+	refreshes int
+}
+
+func (s *tokenServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.refreshes++
+	s.mu.Unlock()
+	if r.FormValue("grant_type") != "refresh_token" || r.FormValue("refresh_token") != "refresh-1" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token":  s.access,
+		"refresh_token": "refresh-2",
+		"expires_in":    7200,
+		"created_at":    time.Now().Unix(),
+	})
+}
+
+// newOAuth2Client returns a client of a gitlabtest.Server accepting only
+// the access token the tokenServer hands out, authenticated with token
+func newOAuth2Client(t *testing.T, token gitlab.OAuth2Token) (*gitlabtest.Server, *tokenServer, *gitlab.OAuth2Credentials, gitlab.GitlabClient) {
+	t.Helper()
+	fake := gitlabtest.NewServer()
+	t.Cleanup(fake.Close)
+	fake.Token = "access-2"
+	tokens := &tokenServer{access: fake.Token}
+	srv := httptest.NewServer(tokens)
+	t.Cleanup(srv.Close)
+
+	creds := gitlab.NewOAuth2Credentials(gitlab.OAuth2Config{ClientID: "app", TokenURL: srv.URL}, token)
+	client, err := gitlab.NewClient(
+		gitlab.WithBaseURL(fake.URL()),
+		gitlab.WithCredentials(creds),
+		gitlab.WithLogger(gitlab.NopLogger()),
+	)
+	require.NoError(t, err)
+	return fake, tokens, creds, client
+}
+
+func TestOAuth2RefreshBeforeExpiry(t *testing.T) {
+
+	fake, tokens, creds, client := newOAuth2Client(t, gitlab.OAuth2Token{
+		AccessToken:  "access-1",
+		RefreshToken: "refresh-1",
+		Expiry:       time.Now().Add(10 * time.Second),
+	})
+	g := fake.AddGroup(gitlab.Group{Path: "platform"})
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetGroup(g.ID)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, tokens.refreshes)
+	assert.Equal(t, "access-2", creds.Token().AccessToken)
+	assert.Equal(t, "refresh-2", creds.Token().RefreshToken)
+	assert.True(t, creds.Token().Expiry.After(time.Now().Add(time.Hour)))
+}
+
+func TestOAuth2RefreshOnUnauthorized(t *testing.T) {
+
+	fake, tokens, creds, client := newOAuth2Client(t, gitlab.OAuth2Token{
+		AccessToken:  "access-1",
+		RefreshToken: "refresh-1",
+	})
+	g := fake.AddGroup(gitlab.Group{Path: "platform"})
+
+	_, err := client.GetGroup(g.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, tokens.refreshes)
+	assert.Equal(t, "access-2", creds.Token().AccessToken)
+
+	// when the refresh fails too the 401 is returned
+	fake.Token = "access-3"
+	_, err = client.GetGroup(g.ID)
+	assert.True(t, gitlab.IsUnauthorized(err), "got %v", err)
+	assert.Equal(t, 2, tokens.refreshes)
+}
+
+func TestOAuth2ConcurrentRefresh(t *testing.T) {
+
+	fake, tokens, _, client := newOAuth2Client(t, gitlab.OAuth2Token{
+		AccessToken:  "access-1",
+		RefreshToken: "refresh-1",
+	})
+	g := fake.AddGroup(gitlab.Group{Path: "platform"})
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = client.GetGroupWithContext(context.Background(), g.ID)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, tokens.refreshes, "requests rejected together share one refresh")
+}
+
+// headerServer records the credentials headers of the last request
+type headerServer struct {
+	mu     sync.Mutex
+	header http.Header
+}
+
+func (s *headerServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.header = http.Header{}
+	for _, name := range []string{"PRIVATE-TOKEN", "JOB-TOKEN", "Authorization"} {
+		if v := r.Header.Get(name); v != "" {
+			s.header.Set(name, v)
+		}
+	}
+	s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(`{"id":1}`))
+}
+
+// sentCredentials builds a client from opts, sends a request and returns
+// the credentials headers it carried
+func sentCredentials(t *testing.T, srv *headerServer, opts ...gitlab.ClientOption) http.Header {
+	t.Helper()
+	client, err := gitlab.NewClient(append(opts, gitlab.WithLogger(gitlab.NopLogger()))...)
+	require.NoError(t, err)
+	_, err = client.GetGroup(1)
+	require.NoError(t, err)
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return srv.header
+}
+
+func TestWithEnvironment(t *testing.T) {
+
+	srv := &headerServer{}
+	hs := httptest.NewServer(srv)
+	defer hs.Close()
+
+	for name, tc := range map[string]struct {
+		env  map[string]string
+		opts []gitlab.ClientOption
+		want http.Header
+	}{
+		"private token first": {
+			env:  map[string]string{"GITLAB_TOKEN": "glpat-env", "CI_JOB_TOKEN": "job-env"},
+			want: http.Header{"Private-Token": {"glpat-env"}},
+		},
+		"job token": {
+			env:  map[string]string{"CI_JOB_TOKEN": "job-env"},
+			want: http.Header{"Job-Token": {"job-env"}},
+		},
+		"earlier option kept": {
+			env:  map[string]string{"GITLAB_TOKEN": "glpat-env"},
+			opts: []gitlab.ClientOption{gitlab.WithOAuthToken("oauth-explicit"), gitlab.WithEnvironment()},
+			want: http.Header{"Authorization": {"Bearer oauth-explicit"}},
+		},
+		"later option wins": {
+			env:  map[string]string{"CI_JOB_TOKEN": "job-env"},
+			opts: []gitlab.ClientOption{gitlab.WithEnvironment(), gitlab.WithToken("glpat-explicit")},
+			want: http.Header{"Private-Token": {"glpat-explicit"}},
+		},
+		"no token": {
+			want: http.Header{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for _, key := range []string{"GITLAB_TOKEN", "CI_JOB_TOKEN"} {
+				t.Setenv(key, tc.env[key])
+			}
+			t.Setenv("CI_SERVER_URL", hs.URL)
+			opts := tc.opts
+			if opts == nil {
+				opts = []gitlab.ClientOption{gitlab.WithEnvironment()}
+			}
+			assert.Equal(t, tc.want, sentCredentials(t, srv, opts...))
+		})
+	}
+}
+
+func TestWithConfigFile(t *testing.T) {
+
+	srv := &headerServer{}
+	hs := httptest.NewServer(srv)
+	defer hs.Close()
+	dir := t.TempDir()
+
+	for name, tc := range map[string]struct {
+		config  string
+		opts    []gitlab.ClientOption
+		want    http.Header
+		wantErr string
+	}{
+		"private token": {
+			config: "base_url: " + hs.URL + "\ntoken: glpat-file\n",
+			want:   http.Header{"Private-Token": {"glpat-file"}},
+		},
+		"job token": {
+			config: "base_url: " + hs.URL + "\ntoken: job-file\ntoken_type: job\n",
+			want:   http.Header{"Job-Token": {"job-file"}},
+		},
+		"oauth token": {
+			config: "base_url: " + hs.URL + "\ntoken: oauth-file\ntoken_type: oauth\n",
+			want:   http.Header{"Authorization": {"Bearer oauth-file"}},
+		},
+		"earlier options kept": {
+			config: "base_url: https://elsewhere.example.com\ntoken: glpat-file\n",
+			opts:   []gitlab.ClientOption{gitlab.WithBaseURL(hs.URL), gitlab.WithJobToken("job-explicit")},
+			want:   http.Header{"Job-Token": {"job-explicit"}},
+		},
+		"unknown token type": {
+			config:  "token: x\ntoken_type: ssh\n",
+			wantErr: `unknown token_type "ssh"`,
+		},
+		"malformed": {
+			config:  "token: [",
+			wantErr: "parsing config file",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name+".yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.config), 0600))
+			opts := append(tc.opts, gitlab.WithConfigFile(path))
+			if tc.wantErr != "" {
+				_, err := gitlab.NewClient(opts...)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			assert.Equal(t, tc.want, sentCredentials(t, srv, opts...))
+		})
+	}
+
+	_, err := gitlab.NewClient(gitlab.WithConfigFile(filepath.Join(dir, "missing.yaml")))
+	assert.Error(t, err, "an explicit path must exist")
+}
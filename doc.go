@@ -0,0 +1,7 @@
+// Package gitlab is a small client for the GitLab REST API.
+//
+// Errors: the package never logs-and-exits.  Every failure is returned to
+// the caller; non-2xx responses come back as *RequestError (see IsNotFound,
+// IsForbidden, ...) and undecodable bodies as *DecodeError, both of which
+// can be inspected with errors.As.
+package gitlab
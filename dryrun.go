@@ -0,0 +1,171 @@
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PlannedChange is a mutating request skipped by a dry run
+type PlannedChange struct {
+	Method string
+	// Path is relative to the API path and includes any query string,
+	// e.g. "/projects/42/protected_branches/main"
+	Path string
+	// Body is the decoded JSON or form body, nil when there was none
+	Body interface{}
+	At   time.Time
+}
+
+// DryRun collects the changes a client would have made, see WithDryRun
+type DryRun struct {
+	mu      sync.Mutex
+	changes []PlannedChange
+}
+
+// NewDryRun returns an empty DryRun
+func NewDryRun() *DryRun {
+	return &DryRun{}
+}
+
+// Changes returns the planned changes in the order they were requested
+func (d *DryRun) Changes() []PlannedChange {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]PlannedChange(nil), d.changes...)
+}
+
+// Reset forgets the planned changes
+func (d *DryRun) Reset() {
+	d.mu.Lock()
+	d.changes = nil
+	d.mu.Unlock()
+}
+
+func (d *DryRun) add(change PlannedChange) {
+	d.mu.Lock()
+	d.changes = append(d.changes, change)
+	d.mu.Unlock()
+}
+
+// WithDryRun stops the client from changing anything on GitLab.  Every
+// POST, PUT, PATCH and DELETE to the API is recorded in d instead of being
+// sent, and answered with a synthetic success: 201 (POST) or 200 (PUT,
+// PATCH) echoing the request body, or 204 (DELETE).  Calls that decode the
+// response therefore return the values they asked for, with a zero ID.
+// Synthetic responses carry an X-Dry-Run header.  GET requests still reach
+// GitLab.
+func WithDryRun(d *DryRun) ClientOption {
+	return func(c *clientConfig) error {
+		if d == nil {
+			d = NewDryRun()
+		}
+		c.dryRun = d
+		return nil
+	}
+}
+
+// dryRunTransport answers mutating API requests without sending them
+type dryRunTransport struct {
+	next    http.RoundTripper
+	dryRun  *DryRun
+	apiPath string
+	logger  Logger
+}
+
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	path := req.URL.EscapedPath()
+	relative := apiRelativePath(path, t.apiPath)
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return t.next.RoundTrip(req)
+	}
+	if relative == path {
+		// not an API call, e.g. refreshing an OAuth token
+		return t.next.RoundTrip(req)
+	}
+
+	raw, err := drainRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	t.logger.Info("dry run, request not sent", "method", req.Method, "path", relative)
+	if req.URL.RawQuery != "" {
+		relative += "?" + req.URL.RawQuery
+	}
+	body, echo := decodePlannedBody(req.Header.Get("Content-Type"), raw)
+	t.dryRun.add(PlannedChange{
+		Method: req.Method,
+		Path:   relative,
+		Body:   body,
+		At:     time.Now(),
+	})
+
+	status := http.StatusOK
+	switch req.Method {
+	case http.MethodPost:
+		status = http.StatusCreated
+	case http.MethodDelete:
+		status, echo = http.StatusNoContent, nil
+	}
+	header := http.Header{}
+	header.Set("X-Dry-Run", "1")
+	if echo != nil {
+		header.Set("Content-Type", "application/json")
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(echo)),
+		ContentLength: int64(len(echo)),
+		Request:       req,
+	}, nil
+}
+
+// decodePlannedBody decodes a JSON or form request body, returning it along
+// with its JSON encoding for the synthetic response
+func decodePlannedBody(contentType string, raw []byte) (interface{}, []byte) {
+
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil, []byte("{}")
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(string(raw)); err == nil {
+			fields := formFields(form)
+			echo, _ := json.Marshal(fields)
+			return fields, echo
+		}
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return string(raw), []byte("{}")
+	}
+	return body, raw
+}
+
+// formFields flattens single valued form fields to strings
+func formFields(form url.Values) map[string]interface{} {
+	fields := make(map[string]interface{}, len(form))
+	for k, v := range form {
+		if len(v) == 1 {
+			fields[k] = v[0]
+		} else {
+			fields[k] = v
+		}
+	}
+	return fields
+}
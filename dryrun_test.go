@@ -0,0 +1,70 @@
+package gitlab_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	gitlab "github.com/maahsome/gitlab-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunSendsNoChanges(t *testing.T) {
+
+	dryRun := gitlab.NewDryRun()
+	fake, client := newFakeClient(t, gitlab.WithDryRun(dryRun))
+	alice := fake.AddUser("alice", "Alice")
+	g := fake.AddGroup(gitlab.Group{Path: "platform"})
+	p := fake.AddProject(g.ID, gitlab.Project{Path: "site"})
+	fake.AddMember("groups", g.ID, alice, int(gitlab.DeveloperAccess))
+	fake.SetVariable("projects", p.ID, gitlab.Variable{Key: "DEPLOY_TOKEN", Value: "old"})
+
+	// reads reach the server
+	group, err := client.GetGroup(g.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "platform", group.Path)
+	value, err := client.GetVariableFrom(p.ID, "projects", "DEPLOY_TOKEN")
+	require.NoError(t, err)
+	assert.Contains(t, value, `"value":"old"`)
+	reads := fake.Requests(http.MethodGet)
+	assert.Equal(t, 2, reads)
+
+	_, err = client.UpdateVariableFrom(p.ID, "projects", "DEPLOY_TOKEN", "new")
+	require.NoError(t, err)
+	mirror, err := client.CreateProjectMirror(p.ID, "https://example.com/site.git")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/site.git", mirror.URL)
+	assert.Zero(t, mirror.ID)
+	require.NoError(t, client.Members().RemoveGroupMember(g.ID, alice))
+
+	assert.Equal(t, reads, fake.Requests(""), "only the reads reached the server")
+	v, ok := fake.Variable("projects", p.ID, "DEPLOY_TOKEN")
+	require.True(t, ok)
+	assert.Equal(t, "old", v.Value)
+	assert.Empty(t, fake.Mirrors(p.ID))
+	_, ok = fake.MemberAccessLevel("groups", g.ID, alice)
+	assert.True(t, ok)
+
+	changes := dryRun.Changes()
+	require.Len(t, changes, 3)
+	assert.Equal(t, http.MethodPut, changes[0].Method)
+	assert.Equal(t, fmt.Sprintf("/projects/%d/variables/DEPLOY_TOKEN", p.ID), changes[0].Path)
+	assert.Equal(t, map[string]interface{}{"value": "new"}, changes[0].Body)
+	assert.Equal(t, http.MethodPost, changes[1].Method)
+	assert.Equal(t, fmt.Sprintf("/projects/%d/remote_mirrors", p.ID), changes[1].Path)
+	assert.Equal(t, map[string]interface{}{
+		"url":                     "https://example.com/site.git",
+		"enabled":                 true,
+		"only_protected_branches": true,
+	}, changes[1].Body)
+	assert.Equal(t, http.MethodDelete, changes[2].Method)
+	assert.Equal(t, fmt.Sprintf("/groups/%d/members/%d", g.ID, alice), changes[2].Path)
+	assert.Nil(t, changes[2].Body)
+	for _, change := range changes {
+		assert.False(t, change.At.IsZero())
+	}
+
+	dryRun.Reset()
+	assert.Empty(t, dryRun.Changes())
+}
@@ -0,0 +1,212 @@
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RequestError is returned whenever GitLab answers a request with a
+// non-2xx status code
+type RequestError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	RequestID  string
+	// Message is GitLab's "message" field, flattened to a single line when
+	// GitLab returns a map of validation errors
+	Message string
+	// ErrorText is GitLab's "error" field, with "error_description"
+	// appended when present (OAuth style errors)
+	ErrorText string
+	Body      []byte
+
+	Err error
+}
+
+func (r *RequestError) Error() string {
+	var b strings.Builder
+	if r.Method != "" || r.URL != "" {
+		fmt.Fprintf(&b, "%s %s: ", r.Method, r.URL)
+	}
+	fmt.Fprintf(&b, "status %d", r.StatusCode)
+
+	msg := r.Message
+	if msg == "" {
+		msg = r.ErrorText
+	}
+	if msg == "" && r.Err != nil {
+		msg = r.Err.Error()
+	}
+	if msg != "" {
+		fmt.Fprintf(&b, ": %s", msg)
+	}
+	if r.RequestID != "" {
+		fmt.Fprintf(&b, " (request id %s)", r.RequestID)
+	}
+	return b.String()
+}
+
+func (r *RequestError) Unwrap() error {
+	return r.Err
+}
+
+// IsUnauthorized reports whether err is a GitLab 401 response
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is a GitLab 403 response
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsNotFound reports whether err is a GitLab 404 response
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is a GitLab 409 response, e.g. adding a
+// member that already exists
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsRateLimited reports whether err is a GitLab 429 response
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsServerError reports whether err is a GitLab 5xx response
+func IsServerError(err error) bool {
+	var re *RequestError
+	return errors.As(err, &re) && re.StatusCode >= 500
+}
+
+func hasStatus(err error, statusCode int) bool {
+	var re *RequestError
+	return errors.As(err, &re) && re.StatusCode == statusCode
+}
+
+// DecodeError is returned when a GitLab response body cannot be decoded
+// into the expected type.  Snippet holds the start of the offending body.
+type DecodeError struct {
+	Target  string
+	Snippet string
+
+	Err error
+}
+
+func (d *DecodeError) Error() string {
+	return fmt.Sprintf("decoding %s: %v (body: %q)", d.Target, d.Err, d.Snippet)
+}
+
+func (d *DecodeError) Unwrap() error {
+	return d.Err
+}
+
+const decodeSnippetLength = 256
+
+// encodeBody marshals a request body, passing []byte through untouched.
+// HTML characters are left unescaped so bodies stay readable in logs.
+func encodeBody(body interface{}) ([]byte, error) {
+	if b, ok := body.([]byte); ok {
+		return b, nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(body); err != nil {
+		return nil, fmt.Errorf("encoding %T request body: %w", body, err)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// decodeJSON unmarshals body into v, returning a *DecodeError on failure
+func decodeJSON(body []byte, v interface{}) error {
+	err := json.Unmarshal(body, v)
+	if err == nil {
+		return nil
+	}
+
+	snippet := string(body)
+	if len(snippet) > decodeSnippetLength {
+		snippet = snippet[:decodeSnippetLength] + "..."
+	}
+	return &DecodeError{
+		Target:  strings.TrimPrefix(fmt.Sprintf("%T", v), "*gitlab."),
+		Snippet: snippet,
+		Err:     err,
+	}
+}
+
+type errorBody struct {
+	Message          interface{} `json:"message"`
+	Error            string      `json:"error"`
+	ErrorDescription string      `json:"error_description"`
+}
+
+// checkResponse returns a *RequestError for any non-2xx response
+func checkResponse(resp *resty.Response) error {
+	if resp.IsSuccess() {
+		return nil
+	}
+
+	reqErr := &RequestError{
+		StatusCode: resp.StatusCode(),
+		RequestID:  resp.Header().Get("X-Request-Id"),
+		Body:       resp.Body(),
+		Err:        errors.New(strings.ToLower(http.StatusText(resp.StatusCode()))),
+	}
+	if resp.Request != nil {
+		reqErr.Method = resp.Request.Method
+		reqErr.URL = resp.Request.URL
+	}
+
+	var eb errorBody
+	if json.Unmarshal(resp.Body(), &eb) == nil {
+		reqErr.Message = flattenMessage(eb.Message)
+		reqErr.ErrorText = eb.Error
+		if eb.ErrorDescription != "" {
+			reqErr.ErrorText = strings.TrimSpace(fmt.Sprintf("%s %s", eb.Error, eb.ErrorDescription))
+		}
+	}
+
+	return reqErr
+}
+
+// flattenMessage turns GitLab's message field into a single line.  It is
+// usually a string, but validation failures come back as
+// {"name": ["has already been taken"]}
+func flattenMessage(message interface{}) string {
+	switch m := message.(type) {
+	case nil:
+		return ""
+	case string:
+		return m
+	case []interface{}:
+		parts := make([]string, 0, len(m))
+		for _, v := range m {
+			parts = append(parts, flattenMessage(v))
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			parts = append(parts, fmt.Sprintf("%s %s", k, flattenMessage(m[k])))
+		}
+		return strings.Join(parts, "; ")
+	}
+	return fmt.Sprint(message)
+}
@@ -1,10 +1,22 @@
 package gitlab
 
-import "flag"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
+// PaginationOptions is embedded in every List*Options.  Listing starts at
+// Page (default 1) and follows the next pages until the collection or
+// MaxItems is exhausted.
 type PaginationOptions struct {
-	Page    int `url:"page,omitempty"`
-	PerPage int `url:"per_page,omitempty"`
+	Page     int `url:"page,omitempty"`
+	PerPage  int `url:"per_page,omitempty"`
+	MaxItems int `url:"-"`
+}
+
+func (p PaginationOptions) pager() *PagerOptions {
+	return &PagerOptions{MaxItems: p.MaxItems}
 }
 
 type SortDirection string
@@ -14,6 +26,8 @@ const (
 	SortDirectionDesc SortDirection = "desc"
 )
 
+// SortOptions is embedded in every List*Options, valid OrderBy values
+// depend on the endpoint
 type SortOptions struct {
 	OrderBy string        `url:"order_by,omitempty"`
 	Sort    SortDirection `url:"sort,omitempty"`
@@ -23,6 +37,8 @@ type ResponseWithMessage struct {
 	Message string `json:"message"`
 }
 
+// ResponseMeta describes a single HTTP exchange with GitLab, see
+// WithResponseObserver and RecordResponseMeta
 type ResponseMeta struct {
 	Method     string
 	Url        string
@@ -33,8 +49,16 @@ type ResponseMeta struct {
 	PrevPage   int
 	NextPage   int
 	TotalPages int
-	Total      int
-	Runtime    float64
+	// Total is X-Total, GitLab omits it (and TotalPages) for very large
+	// collections
+	Total int
+	// Runtime is GitLab's own processing time in seconds (X-Runtime)
+	Runtime float64
+	// Duration is the round trip as seen by the client
+	Duration time.Duration
+	// Cached is set when the body came from the WithCache cache, RequestId
+	// is then empty for a fresh hit, or the revalidating request's for a 304
+	Cached bool
 }
 
 type Message struct {
@@ -42,10 +66,75 @@ type Message struct {
 }
 
 const (
-	dateLayout = "2006-01-02T15:04:05-07:00"
+	dateLayout    = "2006-01-02T15:04:05-07:00"
+	isoDateLayout = "2006-01-02"
 )
 
-var (
-	skipCertVerify = flag.Bool("gitlab.skip-cert-check", false,
-		`If set to true, gitlab client will skip certificate checking for https, possibly exposing your system to MITM attack.`)
-)
+// ISODate is a calendar date GitLab reads and writes as "2006-01-02", e.g.
+// a membership's expires_at
+type ISODate time.Time
+
+// Date returns a pointer to the ISODate of year, month and day, for
+// optional *ISODate option fields
+func Date(year int, month time.Month, day int) *ISODate {
+	d := ISODate(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+	return &d
+}
+
+func (d ISODate) String() string {
+	return time.Time(d).Format(isoDateLayout)
+}
+
+func (d ISODate) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("%q", d.String())), nil
+}
+
+func (d *ISODate) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" || s == "" {
+		return nil
+	}
+	t, err := time.Parse(isoDateLayout, s)
+	if err != nil {
+		return fmt.Errorf("parsing date %q: %w", s, err)
+	}
+	*d = ISODate(t)
+	return nil
+}
+
+func (d ISODate) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+func (d *ISODate) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	t, err := time.Parse(isoDateLayout, s)
+	if err != nil {
+		return fmt.Errorf("parsing date %q: %w", s, err)
+	}
+	*d = ISODate(t)
+	return nil
+}
+
+// Bool returns a pointer to v, for optional *bool option fields
+func Bool(v bool) *bool {
+	return &v
+}
+
+// String returns a pointer to v, for optional *string option fields
+func String(v string) *string {
+	return &v
+}
+
+// Int returns a pointer to v, for optional *int option fields
+func Int(v int) *int {
+	return &v
+}
+
+// Time returns a pointer to v, for optional *time.Time option fields
+func Time(v time.Time) *time.Time {
+	return &v
+}
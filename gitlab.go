@@ -1,47 +1,124 @@
 package gitlab
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/go-resty/resty/v2"
-	"github.com/sirupsen/logrus"
 )
 
+//go:generate go run ./internal/mockgen
+
+// GitlabClient is the whole API: raw requests, an accessor per resource
+// service and, for compatibility, the resource methods it had before the
+// services.  That method set is frozen, newer calls are only reached
+// through the accessors: client.Groups().DeleteGroup(...)
 type GitlabClient interface {
 	GetProperty(property string) string
 	SetProperty(property string, value string) string
 	Get(uri string) (string, error)
+	GetWithContext(ctx context.Context, uri string) (string, error)
+	Paginate(ctx context.Context, uri string, opts *PagerOptions, fn func(item json.RawMessage) error) error
+	Iterate(ctx context.Context, uri string, opts *PagerOptions) *PageIterator
 	Delete(uri string) (string, error)
+	DeleteWithContext(ctx context.Context, uri string) (string, error)
+	Post(uri string, body interface{}, out interface{}) error
+	PostWithContext(ctx context.Context, uri string, body interface{}, out interface{}) error
+	Put(uri string, body interface{}, out interface{}) error
+	PutWithContext(ctx context.Context, uri string, body interface{}, out interface{}) error
+	Patch(uri string, body interface{}, out interface{}) error
+	PatchWithContext(ctx context.Context, uri string, body interface{}, out interface{}) error
+	Do(method string, uri string, query interface{}, body interface{}, out interface{}) (ResponseMeta, error)
+	DoWithContext(ctx context.Context, method string, uri string, query interface{}, body interface{}, out interface{}) (ResponseMeta, error)
+
+	Projects() ProjectsService
+	Groups() GroupsService
+	Members() MembersService
+	Pipelines() PipelinesService
+	Jobs() JobsService
+	Variables() VariablesService
+	MergeRequests() MergeRequestsService
+	RepositoryFiles() RepositoryFilesService
+	Users() UsersService
+
 	GetUsers(search string) (string, error)
+	GetUsersWithContext(ctx context.Context, search string) (string, error)
+	ListUsers(opts *ListUsersOptions) (UserList, error)
+	ListUsersWithContext(ctx context.Context, opts *ListUsersOptions) (UserList, error)
 	GetGroup(groupID int) (Group, error)
+	GetGroupWithContext(ctx context.Context, groupID int) (Group, error)
 	GetGroups(search string) (GroupList, error)
+	GetGroupsWithContext(ctx context.Context, search string) (GroupList, error)
+	ListGroups(opts *ListGroupsOptions) (GroupList, error)
+	ListGroupsWithContext(ctx context.Context, opts *ListGroupsOptions) (GroupList, error)
 	GetSubGroups(groupID int) (GroupList, error)
+	GetSubGroupsWithContext(ctx context.Context, groupID int) (GroupList, error)
+	ListSubGroups(groupID int, opts *ListGroupsOptions) (GroupList, error)
+	ListSubGroupsWithContext(ctx context.Context, groupID int, opts *ListGroupsOptions) (GroupList, error)
 	GetDescendantGroups(groupID int) (GroupList, error)
+	GetDescendantGroupsWithContext(ctx context.Context, groupID int) (GroupList, error)
+	ListDescendantGroups(groupID int, opts *ListGroupsOptions) (GroupList, error)
+	ListDescendantGroupsWithContext(ctx context.Context, groupID int, opts *ListGroupsOptions) (GroupList, error)
 	GetGroupProjects(groupID int) (ProjectList, error)
+	GetGroupProjectsWithContext(ctx context.Context, groupID int) (ProjectList, error)
+	ListGroupProjects(groupID int, opts *ListGroupProjectsOptions) (ProjectList, error)
+	ListGroupProjectsWithContext(ctx context.Context, groupID int, opts *ListGroupProjectsOptions) (ProjectList, error)
 	GetGroupMembers(group int) (string, error)
+	GetGroupMembersWithContext(ctx context.Context, group int) (string, error)
+	ListGroupMembers(groupID int, opts *ListMembersOptions) (Members, error)
+	ListGroupMembersWithContext(ctx context.Context, groupID int, opts *ListMembersOptions) (Members, error)
 	AddGroupMember(groupID, userID, accessLevel int) (string, error)
+	AddGroupMemberWithContext(ctx context.Context, groupID, userID, accessLevel int) (string, error)
 	GetForcePushSetting(projectID int, protectedBranch string) (bool, error)
+	GetForcePushSettingWithContext(ctx context.Context, projectID int, protectedBranch string) (bool, error)
 	GetProjectID(projectPath string) (int, error)
+	GetProjectIDWithContext(ctx context.Context, projectPath string) (int, error)
 	GetProject(projectID int) (Project, error)
+	GetProjectWithContext(ctx context.Context, projectID int) (Project, error)
 	GetProjectMembers(project int) (string, error)
+	GetProjectMembersWithContext(ctx context.Context, project int) (string, error)
+	ListProjectMembers(projectID int, opts *ListMembersOptions) (Members, error)
+	ListProjectMembersWithContext(ctx context.Context, projectID int, opts *ListMembersOptions) (Members, error)
 	AddProjectMember(projectID, userID, accessLevel int) (string, error)
+	AddProjectMemberWithContext(ctx context.Context, projectID, userID, accessLevel int) (string, error)
 	DeleteProject(projectID int) error
+	DeleteProjectWithContext(ctx context.Context, projectID int) error
 	GetProjectMirrors(projectID int) (ProjectMirrors, error)
+	GetProjectMirrorsWithContext(ctx context.Context, projectID int) (ProjectMirrors, error)
 	GetGroupID(groupPath string) (int, error)
+	GetGroupIDWithContext(ctx context.Context, groupPath string) (int, error)
 	CreateProject(groupID int, projectPath string, visibility string) (Project, error)
+	CreateProjectWithContext(ctx context.Context, groupID int, projectPath string, visibility string) (Project, error)
 	DeleteProtectedBranch(projectID int, protectedBranch string) (bool, error)
+	DeleteProtectedBranchWithContext(ctx context.Context, projectID int, protectedBranch string) (bool, error)
 	ProtectBranch(projectID int, protectedBranch string) (bool, error)
+	ProtectBranchWithContext(ctx context.Context, projectID int, protectedBranch string) (bool, error)
 	CreateProjectMirror(projectID int, mirrorURL string) (ProjectMirror, error)
+	CreateProjectMirrorWithContext(ctx context.Context, projectID int, mirrorURL string) (ProjectMirror, error)
 	UpdateProjectMirror(projectID int, mirrorID int) (ProjectMirror, error)
+	UpdateProjectMirrorWithContext(ctx context.Context, projectID int, mirrorID int) (ProjectMirror, error)
 	CreateMergeRequest(projectID int, title string, sourceBranch string, targetBranch string, description string, squashOnMerge bool, removeSourceBranch bool) (string, error)
+	CreateMergeRequestWithContext(ctx context.Context, projectID int, title string, sourceBranch string, targetBranch string, description string, squashOnMerge bool, removeSourceBranch bool) (string, error)
 	GetPipelines(projectID int, user string, limit int) (Pipelines, error)
+	GetPipelinesWithContext(ctx context.Context, projectID int, user string, limit int) (Pipelines, error)
+	ListPipelines(projectID int, opts *ListPipelinesOptions) (Pipelines, error)
+	ListPipelinesWithContext(ctx context.Context, projectID int, opts *ListPipelinesOptions) (Pipelines, error)
 	GetPipeline(projectID int, pipelineID int) (Pipeline, error)
+	GetPipelineWithContext(ctx context.Context, projectID int, pipelineID int) (Pipeline, error)
 	GetVariableFrom(id int, resource string, variable string) (string, error)
+	GetVariableFromWithContext(ctx context.Context, id int, resource string, variable string) (string, error)
 	GetCicdVariables(projectdID int) (Variables, error)
+	GetCicdVariablesWithContext(ctx context.Context, projectdID int) (Variables, error)
 	GetCicdVariablesFromGroup(groupID int, includeProjects bool) (Variables, error)
+	GetCicdVariablesFromGroupWithContext(ctx context.Context, groupID int, includeProjects bool) (Variables, error)
 	UpdateVariableFrom(id int, resource string, variable string, value string) (string, error)
+	UpdateVariableFromWithContext(ctx context.Context, id int, resource string, variable string, value string) (string, error)
 	GetRepositoryFile(projectSlug string, fileSlug string, ref string) ([]byte, error)
+	GetRepositoryFileWithContext(ctx context.Context, projectSlug string, fileSlug string, ref string) ([]byte, error)
 }
 
 type gitlabClient struct {
@@ -50,24 +127,98 @@ type gitlabClient struct {
 	RepoFeedPath string
 	Token        string
 	Client       *resty.Client
+
+	// credentials, when set, authenticate requests instead of Token
+	credentials CredentialsProvider
+	logger      Logger
+	// dryRun, when set, records mutating requests instead of sending them
+	dryRun *DryRun
 }
 
 // New generate a new gitlab client
 func New(baseUrl, apiPath, token string) GitlabClient {
 
-	// TODO: Add TLS Insecure && Pass in CA CRT for authentication
-	restClient := resty.New()
+	// these options cannot fail
+	client, _ := NewClient(WithBaseURL(baseUrl), WithAPIPath(apiPath), WithToken(token))
+	return client
+}
+
+// NewClient generates a new gitlab client configured by opts
+func NewClient(opts ...ClientOption) (GitlabClient, error) {
+
+	cfg := &clientConfig{
+		retryCount:     defaultRetryCount,
+		retryMinWait:   defaultRetryMinWait,
+		retryMaxWait:   defaultRetryMaxWait,
+		rateLimitFloor: defaultRateLimitFloor,
+	}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.apiPath == "" {
+		cfg.apiPath = "/api/v4"
+	}
+
+	tlsConfig, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	restClient := resty.New().
+		SetTLSClientConfig(tlsConfig)
+	if cfg.proxy != "" {
+		restClient.SetProxy(cfg.proxy)
+	}
+	if cfg.timeout > 0 {
+		restClient.SetTimeout(cfg.timeout)
+	}
+	if cfg.userAgent != "" {
+		restClient.SetHeader("User-Agent", cfg.userAgent)
+	}
+
+	logger := cfg.logger
+	if logger == nil {
+		logger = packageLogger()
+	}
+	restClient.
+		SetLogger(restyLogger{logger}).
+		SetTransport(cfg.transport(restClient.GetClient().Transport, logger))
 
-	if apiPath == "" {
-		apiPath = "/api/v4"
+	limiter := newRateLimiter(cfg.rateLimitFloor, cfg.throttleObserver)
+	restClient.
+		OnBeforeRequest(limiter.beforeRequest).
+		OnAfterResponse(limiter.afterResponse).
+		OnAfterResponse(metaMiddleware(cfg.responseObservers)).
+		OnAfterResponse(logResponse(logger)).
+		OnError(logError(logger))
+	if cfg.retryCount > 0 {
+		restClient.
+			SetRetryCount(cfg.retryCount).
+			SetRetryWaitTime(cfg.retryMinWait).
+			SetRetryMaxWaitTime(cfg.retryMaxWait).
+			SetRetryAfter(limiter.retryAfter(cfg.retryMinWait, cfg.retryMaxWait)).
+			AddRetryCondition(shouldRetry)
 	}
 
-	return &gitlabClient{
-		BaseUrl: baseUrl,
-		ApiPath: apiPath,
-		Token:   token,
-		Client:  restClient,
+	client := &gitlabClient{
+		BaseUrl:     cfg.baseUrl,
+		ApiPath:     cfg.apiPath,
+		Token:       cfg.token,
+		Client:      restClient,
+		credentials: cfg.credentials,
+		logger:      logger,
+		dryRun:      cfg.dryRun,
 	}
+	if oauth, ok := cfg.credentials.(*OAuth2Credentials); ok {
+		oauth.bindClient(cfg.baseUrl, restClient.GetClient())
+	}
+	// runs ahead of resty's own middlewares, so before every attempt
+	restClient.OnBeforeRequest(client.authenticate)
+
+	return client, nil
 }
 
 func (r *gitlabClient) GetProperty(property string) string {
@@ -92,62 +243,263 @@ func (r *gitlabClient) SetProperty(property string, value string) string {
 		return r.ApiPath
 	case "Token":
 		r.Token = value
+		r.credentials = nil
 		return r.Token
 	}
 	return ""
 }
 
-func (r *gitlabClient) Get(uri string) (string, error) {
+// endpoint returns the absolute URL for uri, a path relative to the API
+// path.  BaseUrl may be a bare host, in which case https is assumed.
+// Absolute URLs (pagination Link headers) are re-rooted on BaseUrl so the
+// token is only ever sent to the configured server.
+func (r *gitlabClient) endpoint(uri string) string {
+	if strings.Contains(uri, "://") {
+		uri = r.apiRelative(uri)
+	}
 
-	nextPage := "1"
-	combinedResults := ""
+	base := withScheme(r.BaseUrl)
+	return fmt.Sprintf("%s%s%s", strings.TrimSuffix(base, "/"), r.ApiPath, uri)
+}
 
-	for {
-		// TODO: detect if there are no options passed in, ? verus & for page option
-		fetchUri := fmt.Sprintf("https://%s%s%s&page=%s", r.BaseUrl, r.ApiPath, uri, nextPage)
-		// logrus.Warn(fetchUri)
-		resp, resperr := r.Client.R().
-			SetHeader("PRIVATE-TOKEN", r.Token).
-			Get(fetchUri)
+// withScheme prefixes a bare host with https://
+func withScheme(baseUrl string) string {
+	if !strings.Contains(baseUrl, "://") {
+		return fmt.Sprintf("https://%s", baseUrl)
+	}
+	return baseUrl
+}
 
-		if resperr != nil {
-			logrus.WithError(resperr).Error("Oops")
-			return "", resperr
+// apiRelative strips the scheme, host and API path from an absolute URL
+func (r *gitlabClient) apiRelative(absolute string) string {
+	u, err := url.Parse(absolute)
+	if err != nil {
+		return absolute
+	}
+	rel := u.EscapedPath()
+	if i := strings.Index(rel, r.ApiPath); i >= 0 {
+		rel = rel[i+len(r.ApiPath):]
+	}
+	if u.RawQuery != "" {
+		rel = fmt.Sprintf("%s?%s", rel, u.RawQuery)
+	}
+	return rel
+}
+
+// newRequest returns a request bound to ctx, authenticate adds the
+// credentials when it is sent
+func (r *gitlabClient) newRequest(ctx context.Context) *resty.Request {
+	return r.Client.R().
+		SetContext(ctx)
+}
+
+// authenticate sets the credentials header on each attempt, using the
+// credentials provider when there is one and Token otherwise
+func (r *gitlabClient) authenticate(c *resty.Client, req *resty.Request) error {
+
+	if r.credentials == nil {
+		if r.Token != "" {
+			req.SetHeader("PRIVATE-TOKEN", r.Token)
 		}
+		return nil
+	}
+
+	name, value, err := r.credentials.Header(req.Context())
+	if err != nil {
+		return fmt.Errorf("authenticating request: %w", err)
+	}
+	req.SetHeader(name, value)
+	return nil
+}
 
-		items := strings.TrimPrefix(string(resp.Body()[:]), "[")
-		items = strings.TrimSuffix(items, "]")
-		if combinedResults == "" {
-			combinedResults += items
-		} else {
-			combinedResults += fmt.Sprintf(", %s", items)
+// renewCredentials asks the credentials provider for new credentials after
+// GitLab rejected those resp was sent with, reporting whether it has them
+func (r *gitlabClient) renewCredentials(ctx context.Context, resp *resty.Response) bool {
+
+	renewer, ok := r.credentials.(credentialsRenewer)
+	if !ok {
+		return false
+	}
+	if err := renewer.renew(ctx, resp.Request.Header); err != nil {
+		r.logger.Debug("renewing rejected credentials failed", "error", err)
+		return false
+	}
+	return true
+}
+
+// do sends a single JSON request to uri (relative to the API path) and,
+// when out is non-nil, decodes a successful response body into it.  body is
+// marshalled with encoding/json unless it is already []byte.
+func (r *gitlabClient) do(ctx context.Context, method string, uri string, body interface{}, out interface{}) (*resty.Response, error) {
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = encodeBody(body); err != nil {
+			return nil, err
 		}
-		currentPage := resp.Header().Get("X-Page")
-		nextPage = resp.Header().Get("X-Next-Page")
-		totalPages := resp.Header().Get("X-Total-Pages")
-		if currentPage == totalPages {
-			break
+	}
+	send := func() (*resty.Response, error) {
+		req := r.newRequest(ctx).
+			SetHeader("Content-Type", "application/json")
+		if body != nil {
+			req.SetBody(payload)
+		}
+		return req.Execute(method, r.endpoint(uri))
+	}
+
+	resp, resperr := send()
+	if resperr == nil && resp.StatusCode() == http.StatusUnauthorized && r.renewCredentials(ctx, resp) {
+		// the token was revoked or expired early, try once with a new one
+		resp, resperr = send()
+	}
+	if resperr != nil {
+		return resp, resperr
+	}
+
+	if err := checkResponse(resp); err != nil {
+		return resp, err
+	}
+
+	if out != nil {
+		if err := decodeJSON(resp.Body(), out); err != nil {
+			return resp, err
 		}
 	}
-	return fmt.Sprintf("[%s]", combinedResults), nil
+
+	return resp, nil
+}
+
+func (r *gitlabClient) Get(uri string) (string, error) {
+	return r.GetWithContext(context.Background(), uri)
+}
+
+// GetWithContext returns the body of uri.  List endpoints are walked to the
+// end and returned as a single JSON array, any other body is returned as is.
+func (r *gitlabClient) GetWithContext(ctx context.Context, uri string) (string, error) {
+
+	resp, resperr := r.do(ctx, http.MethodGet, uri, nil, nil)
+	if resperr != nil {
+		return "", resperr
+	}
+	if !isJSONArray(resp.Body()) {
+		return string(resp.Body()[:]), nil
+	}
+
+	items := []json.RawMessage{}
+	if err := r.appendPages(ctx, uri, resp, &items); err != nil {
+		return "", err
+	}
+
+	combinedResults, err := json.Marshal(items)
+	if err != nil {
+		return "", err
+	}
+	return string(combinedResults[:]), nil
+}
+
+// listRaw returns every item of the list endpoint uri as one JSON array
+func (r *gitlabClient) listRaw(ctx context.Context, uri string, opts *PagerOptions) (string, error) {
+
+	items := []json.RawMessage{}
+	if err := r.listAll(ctx, uri, opts, &items); err != nil {
+		return "", err
+	}
+
+	combinedResults, err := json.Marshal(items)
+	if err != nil {
+		return "", err
+	}
+	return string(combinedResults[:]), nil
 }
 
 func (r *gitlabClient) Delete(uri string) (string, error) {
+	return r.DeleteWithContext(context.Background(), uri)
+}
+
+func (r *gitlabClient) DeleteWithContext(ctx context.Context, uri string) (string, error) {
 
 	// https://git.alteryx.com/api/v4/projects/5784/releases/v0.0.6
 	// GL_PAT=$(get-gitlab-api-pat)
 	// curl --request DELETE --header "PRIVATE-TOKEN: ${GL_PAT}" "https://git.alteryx.com/api/v4/projects/5784/releases/v0.0.6"
 
-	deleteUri := fmt.Sprintf("https://%s%s%s", r.BaseUrl, r.ApiPath, uri)
-	// logrus.Warn(fetchUri)
-	resp, resperr := r.Client.R().
-		SetHeader("PRIVATE-TOKEN", r.Token).
-		Delete(deleteUri)
-
+	resp, resperr := r.do(ctx, http.MethodDelete, uri, nil, nil)
 	if resperr != nil {
-		logrus.WithError(resperr).Error("Oops")
 		return "", resperr
 	}
 
 	return string(resp.Body()[:]), nil
 }
+
+// Post sends body as JSON to uri and decodes the response into out, which
+// may be nil
+func (r *gitlabClient) Post(uri string, body interface{}, out interface{}) error {
+	return r.PostWithContext(context.Background(), uri, body, out)
+}
+
+// PostWithContext - Post bound to ctx
+func (r *gitlabClient) PostWithContext(ctx context.Context, uri string, body interface{}, out interface{}) error {
+	_, err := r.DoWithContext(ctx, http.MethodPost, uri, nil, body, out)
+	return err
+}
+
+// Put sends body as JSON to uri and decodes the response into out, which
+// may be nil
+func (r *gitlabClient) Put(uri string, body interface{}, out interface{}) error {
+	return r.PutWithContext(context.Background(), uri, body, out)
+}
+
+// PutWithContext - Put bound to ctx
+func (r *gitlabClient) PutWithContext(ctx context.Context, uri string, body interface{}, out interface{}) error {
+	_, err := r.DoWithContext(ctx, http.MethodPut, uri, nil, body, out)
+	return err
+}
+
+// Patch sends body as JSON to uri and decodes the response into out, which
+// may be nil
+func (r *gitlabClient) Patch(uri string, body interface{}, out interface{}) error {
+	return r.PatchWithContext(context.Background(), uri, body, out)
+}
+
+// PatchWithContext - Patch bound to ctx
+func (r *gitlabClient) PatchWithContext(ctx context.Context, uri string, body interface{}, out interface{}) error {
+	_, err := r.DoWithContext(ctx, http.MethodPatch, uri, nil, body, out)
+	return err
+}
+
+// Do sends an arbitrary request through the client's authentication, retry
+// and error handling, for endpoints the library does not wrap.
+//
+//   - uri is relative to the API path ("/projects/42/hooks")
+//   - query is nil, url.Values or a struct with `url` tags (List*Options)
+//   - body is nil, []byte or a value marshalled as JSON
+//   - out is nil or a pointer the response is decoded into; a GET into a
+//     pointer to a slice follows every page of a list endpoint
+//
+// The returned ResponseMeta describes the first response.
+func (r *gitlabClient) Do(method string, uri string, query interface{}, body interface{}, out interface{}) (ResponseMeta, error) {
+	return r.DoWithContext(context.Background(), method, uri, query, body, out)
+}
+
+// DoWithContext - Do bound to ctx
+func (r *gitlabClient) DoWithContext(ctx context.Context, method string, uri string, query interface{}, body interface{}, out interface{}) (ResponseMeta, error) {
+
+	uri, err := withQuery(uri, query)
+	if err != nil {
+		return ResponseMeta{}, err
+	}
+
+	var meta ResponseMeta
+	resp, resperr := r.do(ctx, method, uri, body, nil)
+	if resp != nil && resp.RawResponse != nil {
+		meta = newResponseMeta(resp)
+	}
+	if resperr != nil || out == nil {
+		return meta, resperr
+	}
+
+	if method == http.MethodGet && isSlicePointer(out) && isJSONArray(resp.Body()) {
+		return meta, r.appendPages(ctx, uri, resp, out)
+	}
+	return meta, decodeJSON(resp.Body(), out)
+}
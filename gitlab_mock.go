@@ -1,11 +1,10 @@
 package gitlab
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
-	"fmt"
 	"strings"
-
-	"github.com/stretchr/testify/mock"
 )
 
 type gitlabMock struct {
@@ -13,29 +12,47 @@ type gitlabMock struct {
 	ApiPath      string
 	RepoFeedPath string
 	Token        string
-	Client       mock.Mock
-}
-
-type RequestError struct {
-	StatusCode int
-
-	Err error
-}
 
-func (r *RequestError) Error() string {
-	return fmt.Sprintf("status %d: err %v", r.StatusCode, r.Err)
+	mocks *ServiceMocks
 }
 
-// NewGitlabMock - Mocking the gitlab interactions
+// NewGitlabMock - Mocking the gitlab interactions with canned responses, see
+// GitlabMock for a mock that records calls and takes per-test expectations.
+// Service calls without a canned answer go to the mocks MockServices
+// returns.
 func NewGitlabMock(baseUrl, apiPath, token string) GitlabClient {
 
 	return &gitlabMock{
 		BaseUrl: baseUrl,
 		ApiPath: apiPath,
 		Token:   token,
+		mocks: &ServiceMocks{
+			ProjectsMock:        &ProjectsMock{},
+			GroupsMock:          &GroupsMock{},
+			MembersMock:         &MembersMock{},
+			PipelinesMock:       &PipelinesMock{},
+			JobsMock:            &JobsMock{},
+			VariablesMock:       &VariablesMock{},
+			MergeRequestsMock:   &MergeRequestsMock{},
+			RepositoryFilesMock: &RepositoryFilesMock{},
+			UsersMock:           &UsersMock{},
+		},
 	}
 }
 
+// MockServices returns the service mocks of a NewGitlabMock client, to
+// program the calls it has no canned answer for:
+//
+//	MockServices(client).GroupsMock.On("CreateGroup", opts).Return(group, nil)
+//
+// It returns nil for any other client.
+func MockServices(client GitlabClient) *ServiceMocks {
+	if gm, ok := client.(*gitlabMock); ok {
+		return gm.mocks
+	}
+	return nil
+}
+
 func (r *gitlabMock) GetProperty(property string) string {
 	switch property {
 	case "BaseUrl":
@@ -75,6 +92,45 @@ func (gm *gitlabMock) Get(uri string) (string, error) {
 	return "some data", nil
 }
 
+// mockPageItems is the canned list every Paginate and Iterate walks
+var mockPageItems = []json.RawMessage{
+	json.RawMessage(`{"id":1,"name":"first"}`),
+	json.RawMessage(`{"id":2,"name":"second"}`),
+	json.RawMessage(`{"id":3,"name":"third"}`),
+}
+
+func (gm *gitlabMock) Paginate(ctx context.Context, uri string, opts *PagerOptions, fn func(item json.RawMessage) error) error {
+
+	it := gm.Iterate(ctx, uri, opts)
+	for it.Next() {
+		if err := fn(it.Item()); err != nil {
+			if errors.Is(err, ErrStopPagination) {
+				return nil
+			}
+			return err
+		}
+	}
+	return it.Err()
+}
+
+func (gm *gitlabMock) Iterate(ctx context.Context, uri string, opts *PagerOptions) *PageIterator {
+
+	if strings.Contains(uri, "error") {
+		return &PageIterator{
+			ctx: ctx,
+			err: &RequestError{
+				StatusCode: 404,
+				Err:        errors.New("not found"),
+			},
+		}
+	}
+	it := &PageIterator{ctx: ctx, page: mockPageItems}
+	if opts != nil {
+		it.maxItems = opts.MaxItems
+	}
+	return it
+}
+
 func (gm *gitlabMock) Delete(uri string) (string, error) {
 
 	// TODO: Return deletion status
@@ -303,3 +359,286 @@ func (gm *gitlabMock) UpdateVariableFrom(id int, resource string, variable strin
 func (gm *gitlabMock) GetRepositoryFile(projectSlug string, fileSlug string, ref string) ([]byte, error) {
 	return []byte{}, nil
 }
+
+func (gm *gitlabMock) GetWithContext(ctx context.Context, uri string) (string, error) {
+	return gm.Get(uri)
+}
+
+func (gm *gitlabMock) DeleteWithContext(ctx context.Context, uri string) (string, error) {
+	return gm.Delete(uri)
+}
+
+func (gm *gitlabMock) GetUsersWithContext(ctx context.Context, search string) (string, error) {
+	return gm.GetUsers(search)
+}
+
+func (gm *gitlabMock) GetGroupWithContext(ctx context.Context, groupID int) (Group, error) {
+	return gm.GetGroup(groupID)
+}
+
+func (gm *gitlabMock) GetGroupsWithContext(ctx context.Context, search string) (GroupList, error) {
+	return gm.GetGroups(search)
+}
+
+func (gm *gitlabMock) GetSubGroupsWithContext(ctx context.Context, groupID int) (GroupList, error) {
+	return gm.GetSubGroups(groupID)
+}
+
+func (gm *gitlabMock) GetDescendantGroupsWithContext(ctx context.Context, groupID int) (GroupList, error) {
+	return gm.GetDescendantGroups(groupID)
+}
+
+func (gm *gitlabMock) GetGroupProjectsWithContext(ctx context.Context, groupID int) (ProjectList, error) {
+	return gm.GetGroupProjects(groupID)
+}
+
+func (gm *gitlabMock) GetGroupMembersWithContext(ctx context.Context, group int) (string, error) {
+	return gm.GetGroupMembers(group)
+}
+
+func (gm *gitlabMock) AddGroupMemberWithContext(ctx context.Context, groupID, userID, accessLevel int) (string, error) {
+	return gm.AddGroupMember(groupID, userID, accessLevel)
+}
+
+func (gm *gitlabMock) GetForcePushSettingWithContext(ctx context.Context, projectID int, protectedBranch string) (bool, error) {
+	return gm.GetForcePushSetting(projectID, protectedBranch)
+}
+
+func (gm *gitlabMock) GetProjectIDWithContext(ctx context.Context, projectPath string) (int, error) {
+	return gm.GetProjectID(projectPath)
+}
+
+func (gm *gitlabMock) GetProjectWithContext(ctx context.Context, projectID int) (Project, error) {
+	return gm.GetProject(projectID)
+}
+
+func (gm *gitlabMock) GetProjectMembersWithContext(ctx context.Context, project int) (string, error) {
+	return gm.GetProjectMembers(project)
+}
+
+func (gm *gitlabMock) AddProjectMemberWithContext(ctx context.Context, projectID, userID, accessLevel int) (string, error) {
+	return gm.AddProjectMember(projectID, userID, accessLevel)
+}
+
+func (gm *gitlabMock) DeleteProjectWithContext(ctx context.Context, projectID int) error {
+	return gm.DeleteProject(projectID)
+}
+
+func (gm *gitlabMock) GetProjectMirrorsWithContext(ctx context.Context, projectID int) (ProjectMirrors, error) {
+	return gm.GetProjectMirrors(projectID)
+}
+
+func (gm *gitlabMock) GetGroupIDWithContext(ctx context.Context, groupPath string) (int, error) {
+	return gm.GetGroupID(groupPath)
+}
+
+func (gm *gitlabMock) CreateProjectWithContext(ctx context.Context, groupID int, projectPath string, visibility string) (Project, error) {
+	return gm.CreateProject(groupID, projectPath, visibility)
+}
+
+func (gm *gitlabMock) DeleteProtectedBranchWithContext(ctx context.Context, projectID int, protectedBranch string) (bool, error) {
+	return gm.DeleteProtectedBranch(projectID, protectedBranch)
+}
+
+func (gm *gitlabMock) ProtectBranchWithContext(ctx context.Context, projectID int, protectedBranch string) (bool, error) {
+	return gm.ProtectBranch(projectID, protectedBranch)
+}
+
+func (gm *gitlabMock) CreateProjectMirrorWithContext(ctx context.Context, projectID int, mirrorURL string) (ProjectMirror, error) {
+	return gm.CreateProjectMirror(projectID, mirrorURL)
+}
+
+func (gm *gitlabMock) UpdateProjectMirrorWithContext(ctx context.Context, projectID int, mirrorID int) (ProjectMirror, error) {
+	return gm.UpdateProjectMirror(projectID, mirrorID)
+}
+
+func (gm *gitlabMock) CreateMergeRequestWithContext(ctx context.Context, projectID int, title string, sourceBranch string, targetBranch string, description string, squashOnMerge bool, removeSourceBranch bool) (string, error) {
+	return gm.CreateMergeRequest(projectID, title, sourceBranch, targetBranch, description, squashOnMerge, removeSourceBranch)
+}
+
+func (gm *gitlabMock) GetPipelinesWithContext(ctx context.Context, projectID int, user string, limit int) (Pipelines, error) {
+	return gm.GetPipelines(projectID, user, limit)
+}
+
+func (gm *gitlabMock) GetPipelineWithContext(ctx context.Context, projectID int, pipelineID int) (Pipeline, error) {
+	return gm.GetPipeline(projectID, pipelineID)
+}
+
+func (gm *gitlabMock) GetVariableFromWithContext(ctx context.Context, id int, resource string, variable string) (string, error) {
+	return gm.GetVariableFrom(id, resource, variable)
+}
+
+func (gm *gitlabMock) GetCicdVariablesWithContext(ctx context.Context, projectdID int) (Variables, error) {
+	return gm.GetCicdVariables(projectdID)
+}
+
+func (gm *gitlabMock) GetCicdVariablesFromGroupWithContext(ctx context.Context, groupID int, includeProjects bool) (Variables, error) {
+	return gm.GetCicdVariablesFromGroup(groupID, includeProjects)
+}
+
+func (gm *gitlabMock) UpdateVariableFromWithContext(ctx context.Context, id int, resource string, variable string, value string) (string, error) {
+	return gm.UpdateVariableFrom(id, resource, variable, value)
+}
+
+func (gm *gitlabMock) GetRepositoryFileWithContext(ctx context.Context, projectSlug string, fileSlug string, ref string) ([]byte, error) {
+	return gm.GetRepositoryFile(projectSlug, fileSlug, ref)
+}
+
+func (gm *gitlabMock) ListGroups(opts *ListGroupsOptions) (GroupList, error) {
+	search := ""
+	if opts != nil {
+		search = opts.Search
+	}
+	return gm.GetGroups(search)
+}
+
+func (gm *gitlabMock) ListGroupsWithContext(ctx context.Context, opts *ListGroupsOptions) (GroupList, error) {
+	return gm.ListGroups(opts)
+}
+
+func (gm *gitlabMock) ListSubGroups(groupID int, opts *ListGroupsOptions) (GroupList, error) {
+	return gm.GetSubGroups(groupID)
+}
+
+func (gm *gitlabMock) ListSubGroupsWithContext(ctx context.Context, groupID int, opts *ListGroupsOptions) (GroupList, error) {
+	return gm.ListSubGroups(groupID, opts)
+}
+
+func (gm *gitlabMock) ListDescendantGroups(groupID int, opts *ListGroupsOptions) (GroupList, error) {
+	return gm.GetDescendantGroups(groupID)
+}
+
+func (gm *gitlabMock) ListDescendantGroupsWithContext(ctx context.Context, groupID int, opts *ListGroupsOptions) (GroupList, error) {
+	return gm.ListDescendantGroups(groupID, opts)
+}
+
+func (gm *gitlabMock) ListGroupProjects(groupID int, opts *ListGroupProjectsOptions) (ProjectList, error) {
+	return gm.GetGroupProjects(groupID)
+}
+
+func (gm *gitlabMock) ListGroupProjectsWithContext(ctx context.Context, groupID int, opts *ListGroupProjectsOptions) (ProjectList, error) {
+	return gm.ListGroupProjects(groupID, opts)
+}
+
+func (gm *gitlabMock) ListGroupMembers(groupID int, opts *ListMembersOptions) (Members, error) {
+	return Members{}, nil
+}
+
+func (gm *gitlabMock) ListGroupMembersWithContext(ctx context.Context, groupID int, opts *ListMembersOptions) (Members, error) {
+	return gm.ListGroupMembers(groupID, opts)
+}
+
+func (gm *gitlabMock) ListProjectMembers(projectID int, opts *ListMembersOptions) (Members, error) {
+	return Members{}, nil
+}
+
+func (gm *gitlabMock) ListProjectMembersWithContext(ctx context.Context, projectID int, opts *ListMembersOptions) (Members, error) {
+	return gm.ListProjectMembers(projectID, opts)
+}
+
+func (gm *gitlabMock) ListUsers(opts *ListUsersOptions) (UserList, error) {
+	return UserList{}, nil
+}
+
+func (gm *gitlabMock) ListUsersWithContext(ctx context.Context, opts *ListUsersOptions) (UserList, error) {
+	return gm.ListUsers(opts)
+}
+
+func (gm *gitlabMock) ListPipelines(projectID int, opts *ListPipelinesOptions) (Pipelines, error) {
+	username, limit := "", 0
+	if opts != nil {
+		username, limit = opts.Username, opts.MaxItems
+	}
+	return gm.GetPipelines(projectID, username, limit)
+}
+
+func (gm *gitlabMock) ListPipelinesWithContext(ctx context.Context, projectID int, opts *ListPipelinesOptions) (Pipelines, error) {
+	return gm.ListPipelines(projectID, opts)
+}
+
+func (gm *gitlabMock) Post(uri string, body interface{}, out interface{}) error {
+	return nil
+}
+
+func (gm *gitlabMock) PostWithContext(ctx context.Context, uri string, body interface{}, out interface{}) error {
+	return gm.Post(uri, body, out)
+}
+
+func (gm *gitlabMock) Put(uri string, body interface{}, out interface{}) error {
+	return nil
+}
+
+func (gm *gitlabMock) PutWithContext(ctx context.Context, uri string, body interface{}, out interface{}) error {
+	return gm.Put(uri, body, out)
+}
+
+func (gm *gitlabMock) Patch(uri string, body interface{}, out interface{}) error {
+	return nil
+}
+
+func (gm *gitlabMock) PatchWithContext(ctx context.Context, uri string, body interface{}, out interface{}) error {
+	return gm.Patch(uri, body, out)
+}
+
+func (gm *gitlabMock) Do(method string, uri string, query interface{}, body interface{}, out interface{}) (ResponseMeta, error) {
+	return ResponseMeta{Method: method, Url: uri}, nil
+}
+
+func (gm *gitlabMock) DoWithContext(ctx context.Context, method string, uri string, query interface{}, body interface{}, out interface{}) (ResponseMeta, error) {
+	return gm.Do(method, uri, query, body, out)
+}
+
+// DeleteGroup answers as an instance without delayed deletion does, the
+// group is removed in the background
+func (gm *gitlabMock) DeleteGroup(groupID int, opts *DeleteGroupOptions) (GroupDeletion, error) {
+
+	if groupID == 0 {
+		return GroupDeletion{}, &RequestError{
+			StatusCode: 404,
+			Err:        errors.New("not found"),
+		}
+	}
+	return GroupDeletion{}, nil
+}
+
+func (gm *gitlabMock) DeleteGroupWithContext(ctx context.Context, groupID int, opts *DeleteGroupOptions) (GroupDeletion, error) {
+	return gm.DeleteGroup(groupID, opts)
+}
+
+// The accessors hand out the canned answers above.  Calls added to the
+// services since have none, they go to the services' testify mocks, which
+// fail them as unexpected unless programmed through MockServices.
+func (gm *gitlabMock) Projects() ProjectsService               { return gm.services() }
+func (gm *gitlabMock) Groups() GroupsService                   { return gm.services() }
+func (gm *gitlabMock) Members() MembersService                 { return gm.services() }
+func (gm *gitlabMock) Pipelines() PipelinesService             { return gm.services() }
+func (gm *gitlabMock) Jobs() JobsService                       { return gm.services() }
+func (gm *gitlabMock) Variables() VariablesService             { return gm.services() }
+func (gm *gitlabMock) MergeRequests() MergeRequestsService     { return gm.services() }
+func (gm *gitlabMock) RepositoryFiles() RepositoryFilesService { return gm.services() }
+func (gm *gitlabMock) Users() UsersService                     { return gm.services() }
+
+// gitlabMockServices answers with gitlabMock's methods, which shadow the
+// service mocks', and falls back on the service mocks
+type gitlabMockServices struct {
+	*gitlabMock
+	*ServiceMocks
+}
+
+// ServiceMocks are the testify mocks behind the accessors of a
+// NewGitlabMock client
+type ServiceMocks struct {
+	*ProjectsMock
+	*GroupsMock
+	*MembersMock
+	*PipelinesMock
+	*JobsMock
+	*VariablesMock
+	*MergeRequestsMock
+	*RepositoryFilesMock
+	*UsersMock
+}
+
+func (gm *gitlabMock) services() gitlabMockServices {
+	return gitlabMockServices{gitlabMock: gm, ServiceMocks: gm.mocks}
+}
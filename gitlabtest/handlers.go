@@ -0,0 +1,1462 @@
+package gitlabtest
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	gitlab "github.com/maahsome/gitlab-go"
+)
+
+// fakeRoutes maps API paths to handlers, "*" matches one (unescaped) path
+// segment and is passed to the handler
+func fakeRoutes() []fakeRoute {
+	route := func(method, pattern string, handler fakeHandler) fakeRoute {
+		return fakeRoute{method: method, pattern: strings.Split(pattern, "/"), handler: handler}
+	}
+	return []fakeRoute{
+		route(http.MethodGet, "user", (*Server).currentUser),
+		route(http.MethodGet, "users", (*Server).listUsers),
+		route(http.MethodGet, "users/*", (*Server).getUser),
+		route(http.MethodGet, "users/*/keys", (*Server).listSSHKeys),
+		route(http.MethodGet, "users/*/gpg_keys", (*Server).listGPGKeys),
+		route(http.MethodGet, "users/*/memberships", (*Server).listUserMemberships),
+		route(http.MethodPost, "users/*/block", (*Server).blockUser),
+		route(http.MethodPost, "users/*/unblock", (*Server).unblockUser),
+		route(http.MethodPost, "users/*/deactivate", (*Server).deactivateUser),
+		route(http.MethodPost, "users/*/activate", (*Server).activateUser),
+
+		route(http.MethodGet, "groups", (*Server).listGroups),
+		route(http.MethodPost, "groups", (*Server).createGroup),
+		route(http.MethodGet, "groups/*", (*Server).getGroup),
+		route(http.MethodPut, "groups/*", (*Server).updateGroup),
+		route(http.MethodDelete, "groups/*", (*Server).deleteGroup),
+		route(http.MethodPost, "groups/*/restore", (*Server).restoreGroup),
+		route(http.MethodPost, "groups/*/transfer", (*Server).transferGroup),
+		route(http.MethodGet, "groups/*/subgroups", (*Server).listSubGroups),
+		route(http.MethodGet, "groups/*/descendant_groups", (*Server).listDescendantGroups),
+		route(http.MethodGet, "groups/*/projects", (*Server).listGroupProjects),
+		route(http.MethodGet, "groups/*/members", (*Server).listMembers),
+		route(http.MethodPost, "groups/*/members", (*Server).addMember),
+		route(http.MethodGet, "groups/*/members/all", (*Server).listAllMembers),
+		route(http.MethodGet, "groups/*/members/*", (*Server).getMember),
+		route(http.MethodPut, "groups/*/members/*", (*Server).updateMember),
+		route(http.MethodDelete, "groups/*/members/*", (*Server).removeMember),
+		route(http.MethodPost, "groups/*/share", (*Server).share),
+		route(http.MethodDelete, "groups/*/share/*", (*Server).unshare),
+		route(http.MethodGet, "groups/*/variables", (*Server).listVariables),
+		route(http.MethodPost, "groups/*/variables", (*Server).createVariable),
+		route(http.MethodGet, "groups/*/variables/*", (*Server).getVariable),
+		route(http.MethodPut, "groups/*/variables/*", (*Server).updateVariable),
+
+		route(http.MethodPost, "projects", (*Server).createProject),
+		route(http.MethodGet, "projects/*", (*Server).getProject),
+		route(http.MethodDelete, "projects/*", (*Server).deleteProject),
+		route(http.MethodGet, "projects/*/members", (*Server).listMembers),
+		route(http.MethodPost, "projects/*/members", (*Server).addMember),
+		route(http.MethodGet, "projects/*/members/all", (*Server).listAllMembers),
+		route(http.MethodGet, "projects/*/members/*", (*Server).getMember),
+		route(http.MethodPut, "projects/*/members/*", (*Server).updateMember),
+		route(http.MethodDelete, "projects/*/members/*", (*Server).removeMember),
+		route(http.MethodPost, "projects/*/share", (*Server).share),
+		route(http.MethodDelete, "projects/*/share/*", (*Server).unshare),
+		route(http.MethodGet, "projects/*/variables", (*Server).listVariables),
+		route(http.MethodPost, "projects/*/variables", (*Server).createVariable),
+		route(http.MethodGet, "projects/*/variables/*", (*Server).getVariable),
+		route(http.MethodPut, "projects/*/variables/*", (*Server).updateVariable),
+		route(http.MethodGet, "projects/*/pipelines", (*Server).listPipelines),
+		route(http.MethodGet, "projects/*/pipelines/*", (*Server).getPipeline),
+		route(http.MethodGet, "projects/*/pipelines/*/jobs", (*Server).listPipelineJobs),
+		route(http.MethodGet, "projects/*/jobs/*", (*Server).getJob),
+		route(http.MethodGet, "projects/*/protected_branches", (*Server).listProtectedBranches),
+		route(http.MethodPost, "projects/*/protected_branches", (*Server).protectBranch),
+		route(http.MethodGet, "projects/*/protected_branches/*", (*Server).getProtectedBranch),
+		route(http.MethodDelete, "projects/*/protected_branches/*", (*Server).unprotectBranch),
+		route(http.MethodGet, "projects/*/remote_mirrors", (*Server).listMirrors),
+		route(http.MethodPost, "projects/*/remote_mirrors", (*Server).createMirror),
+		route(http.MethodPut, "projects/*/remote_mirrors/*", (*Server).updateMirror),
+		route(http.MethodGet, "projects/*/merge_requests", (*Server).listMergeRequests),
+		route(http.MethodPost, "projects/*/merge_requests", (*Server).createMergeRequest),
+		route(http.MethodGet, "projects/*/repository/files/*", (*Server).getFile),
+	}
+}
+
+func (s *Server) currentUser(w http.ResponseWriter, r *http.Request, args []string) {
+
+	u := s.user(s.tokenOwner)
+	if u == nil {
+		fakeError(w, http.StatusForbidden, "403 Forbidden")
+		return
+	}
+	writeJSON(w, http.StatusOK, u)
+}
+
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request, args []string) {
+
+	query := r.URL.Query()
+	search := strings.ToLower(query.Get("search"))
+	active, activeSet := queryBool(r, "active")
+	blocked, blockedSet := queryBool(r, "blocked")
+	external, externalSet := queryBool(r, "external")
+	admins, _ := queryBool(r, "admins")
+	var createdAfter, createdBefore time.Time
+	for key, t := range map[string]*time.Time{"created_after": &createdAfter, "created_before": &createdBefore} {
+		if v := query.Get(key); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				fakeErrorText(w, http.StatusBadRequest, key+" is invalid")
+				return
+			}
+			*t = parsed
+		}
+	}
+
+	users := gitlab.UserList{}
+	for _, u := range s.users {
+		if search != "" && !strings.Contains(strings.ToLower(u.Username), search) && !strings.Contains(strings.ToLower(u.Name), search) {
+			continue
+		}
+		if username := query.Get("username"); username != "" && !strings.EqualFold(u.Username, username) {
+			continue
+		}
+		if activeSet && active != (u.State == "active") {
+			continue
+		}
+		if blockedSet && blocked != (u.State == "blocked") {
+			continue
+		}
+		if externalSet && external != u.External {
+			continue
+		}
+		if admins && !u.IsAdmin {
+			continue
+		}
+		if !createdAfter.IsZero() && (u.CreatedAt == nil || u.CreatedAt.Before(createdAfter)) {
+			continue
+		}
+		if !createdBefore.IsZero() && (u.CreatedAt == nil || u.CreatedAt.After(createdBefore)) {
+			continue
+		}
+		users = append(users, u)
+	}
+	ok := sortItems(w, r, users, "id", "asc", func(i int, orderBy string) (string, bool) {
+		switch orderBy {
+		case "id":
+			return idKey(users[i].ID), true
+		case "name":
+			return users[i].Name, true
+		case "username":
+			return users[i].Username, true
+		}
+		return "", false
+	})
+	if ok {
+		writePage(w, r, users)
+	}
+}
+
+// userByArg looks up the user in a path argument, answering 404 when there
+// is none
+func (s *Server) userByArg(w http.ResponseWriter, arg string) (*gitlab.User, bool) {
+	if id, err := strconv.Atoi(arg); err == nil {
+		if u := s.user(id); u != nil {
+			return u, true
+		}
+	}
+	fakeError(w, http.StatusNotFound, "404 User Not Found")
+	return nil, false
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request, args []string) {
+	if u, ok := s.userByArg(w, args[0]); ok {
+		writeJSON(w, http.StatusOK, u)
+	}
+}
+
+func (s *Server) listSSHKeys(w http.ResponseWriter, r *http.Request, args []string) {
+	if u, ok := s.userByArg(w, args[0]); ok {
+		writePage(w, r, append(gitlab.SSHKeys{}, s.sshKeys[u.ID]...))
+	}
+}
+
+func (s *Server) listGPGKeys(w http.ResponseWriter, r *http.Request, args []string) {
+	if u, ok := s.userByArg(w, args[0]); ok {
+		writePage(w, r, append(gitlab.GPGKeys{}, s.gpgKeys[u.ID]...))
+	}
+}
+
+func (s *Server) listUserMemberships(w http.ResponseWriter, r *http.Request, args []string) {
+
+	u, ok := s.userByArg(w, args[0])
+	if !ok {
+		return
+	}
+	sourceType := r.URL.Query().Get("type")
+	switch sourceType {
+	case "", "Project", "Namespace":
+	default:
+		fakeErrorText(w, http.StatusBadRequest, "type does not have a valid value")
+		return
+	}
+
+	memberships := gitlab.UserMemberships{}
+	for _, g := range s.groups {
+		for _, m := range s.members[fmt.Sprintf("groups/%d", g.ID)] {
+			if m.ID == u.ID && sourceType != "Project" {
+				memberships = append(memberships, gitlab.UserMembership{SourceID: g.ID, SourceName: g.Name, SourceType: "Namespace", AccessLevel: m.AccessLevel})
+			}
+		}
+	}
+	for _, p := range s.projects {
+		for _, m := range s.members[fmt.Sprintf("projects/%d", p.ID)] {
+			if m.ID == u.ID && sourceType != "Namespace" {
+				memberships = append(memberships, gitlab.UserMembership{SourceID: p.ID, SourceName: p.Name, SourceType: "Project", AccessLevel: m.AccessLevel})
+			}
+		}
+	}
+	writePage(w, r, memberships)
+}
+
+func (s *Server) blockUser(w http.ResponseWriter, r *http.Request, args []string) {
+	s.setUserState(w, args[0], "blocked", "", "")
+}
+
+func (s *Server) unblockUser(w http.ResponseWriter, r *http.Request, args []string) {
+	s.setUserState(w, args[0], "active", "deactivated", "403 Forbidden - A deactivated user cannot be unblocked by the API")
+}
+
+func (s *Server) deactivateUser(w http.ResponseWriter, r *http.Request, args []string) {
+	s.setUserState(w, args[0], "deactivated", "blocked", "403 Forbidden - A blocked user cannot be deactivated by the API")
+}
+
+func (s *Server) activateUser(w http.ResponseWriter, r *http.Request, args []string) {
+	s.setUserState(w, args[0], "active", "blocked", "403 Forbidden - A blocked user must be unblocked to be activated")
+}
+
+// setUserState moves a user to state, refusing with a 403 message when the
+// user is in the state forbidden, as GitLab does e.g. for deactivating a
+// blocked user
+func (s *Server) setUserState(w http.ResponseWriter, arg, state, forbidden, message string) {
+
+	u, ok := s.userByArg(w, arg)
+	if !ok {
+		return
+	}
+	if forbidden != "" && u.State == forbidden {
+		fakeError(w, http.StatusForbidden, message)
+		return
+	}
+	u.State = state
+	writeJSON(w, http.StatusCreated, true)
+}
+
+func (s *Server) listGroups(w http.ResponseWriter, r *http.Request, args []string) {
+	s.writeGroups(w, r, s.groups)
+}
+
+func (s *Server) getGroup(w http.ResponseWriter, r *http.Request, args []string) {
+	g := s.groupByRef(args[0])
+	if g == nil {
+		fakeError(w, http.StatusNotFound, "404 Group Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, g)
+}
+
+func (s *Server) createGroup(w http.ResponseWriter, r *http.Request, args []string) {
+
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	name, _ := bodyString(body, "name")
+	groupPath, _ := bodyString(body, "path")
+	switch {
+	case name == "":
+		fakeErrorText(w, http.StatusBadRequest, "name is missing")
+		return
+	case groupPath == "":
+		fakeErrorText(w, http.StatusBadRequest, "path is missing")
+		return
+	}
+	g := gitlab.Group{Name: name, Path: groupPath, Visibility: "private", CreatedAt: time.Now().UTC()}
+	if parentID, ok := bodyInt(body, "parent_id"); ok && parentID != 0 {
+		if s.group(parentID) == nil {
+			fakeError(w, http.StatusNotFound, "404 Parent Not Found")
+			return
+		}
+		g.ParentID = parentID
+	}
+	if !applyGroupSettings(w, &g, body) {
+		return
+	}
+	s.fillGroup(&g)
+	if s.groupByRef(g.FullPath) != nil {
+		fakeError(w, http.StatusBadRequest, map[string][]string{"path": {"has already been taken"}})
+		return
+	}
+	g.ID = s.nextID()
+	s.groups = append(s.groups, g)
+	writeJSON(w, http.StatusCreated, g)
+}
+
+func (s *Server) updateGroup(w http.ResponseWriter, r *http.Request, args []string) {
+
+	g := s.groupByRef(args[0])
+	if g == nil {
+		fakeError(w, http.StatusNotFound, "404 Group Not Found")
+		return
+	}
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	updated := *g
+	if name, ok := bodyString(body, "name"); ok && name != "" {
+		updated.Name = name
+	}
+	if groupPath, ok := bodyString(body, "path"); ok && groupPath != "" {
+		updated.Path = groupPath
+	}
+	if !applyGroupSettings(w, &updated, body) {
+		return
+	}
+	s.fillGroup(&updated)
+	if other := s.groupByRef(updated.FullPath); other != nil && other.ID != g.ID {
+		fakeError(w, http.StatusBadRequest, map[string][]string{"path": {"has already been taken"}})
+		return
+	}
+	*g = updated
+	s.refreshPaths(g.ID)
+	writeJSON(w, http.StatusOK, g)
+}
+
+func (s *Server) deleteGroup(w http.ResponseWriter, r *http.Request, args []string) {
+
+	g := s.groupByRef(args[0])
+	if g == nil {
+		fakeError(w, http.StatusNotFound, "404 Group Not Found")
+		return
+	}
+	permanently, _ := queryBool(r, "permanently_remove")
+	switch {
+	case permanently && g.MarkedForDeletionOn == nil:
+		fakeError(w, http.StatusBadRequest, "Group must be marked for deletion first.")
+		return
+	case permanently && r.URL.Query().Get("full_path") != g.FullPath:
+		fakeError(w, http.StatusBadRequest, "`full_path` is incorrect. You must enter the complete path for the group.")
+		return
+	case !permanently && s.DelayedGroupDeletion && g.MarkedForDeletionOn != nil:
+		fakeError(w, http.StatusBadRequest, "Group has been already marked for deletion")
+		return
+	case !permanently && s.DelayedGroupDeletion:
+		g.MarkedForDeletionOn = time.Now().UTC().Format(dateLayout)
+	default:
+		s.removeGroup(g.ID)
+	}
+	fakeError(w, http.StatusAccepted, "202 Accepted")
+}
+
+func (s *Server) restoreGroup(w http.ResponseWriter, r *http.Request, args []string) {
+
+	g := s.groupByRef(args[0])
+	if g == nil {
+		fakeError(w, http.StatusNotFound, "404 Group Not Found")
+		return
+	}
+	if g.MarkedForDeletionOn == nil {
+		fakeError(w, http.StatusBadRequest, "Group has not been marked for deletion")
+		return
+	}
+	g.MarkedForDeletionOn = nil
+	writeJSON(w, http.StatusCreated, g)
+}
+
+func (s *Server) transferGroup(w http.ResponseWriter, r *http.Request, args []string) {
+
+	g := s.groupByRef(args[0])
+	if g == nil {
+		fakeError(w, http.StatusNotFound, "404 Group Not Found")
+		return
+	}
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	parentID, _ := bodyInt(body, "group_id")
+	if parentID != 0 {
+		if s.group(parentID) == nil {
+			fakeError(w, http.StatusNotFound, "404 Group Not Found")
+			return
+		}
+		if parentID == g.ID {
+			fakeError(w, http.StatusBadRequest, "Transfer failed: Cannot transfer group to itself.")
+			return
+		}
+		for _, d := range s.descendants(g.ID) {
+			if d.ID == parentID {
+				fakeError(w, http.StatusBadRequest, "Transfer failed: Cannot transfer group to one of its subgroup.")
+				return
+			}
+		}
+	}
+	moved := *g
+	moved.ParentID = parentID
+	s.fillGroup(&moved)
+	if s.groupByRef(moved.FullPath) != nil || s.projectByRef(moved.FullPath) != nil {
+		fakeError(w, http.StatusBadRequest, "Transfer failed: The parent group already has a subgroup or a project with the same path.")
+		return
+	}
+	*g = moved
+	s.refreshPaths(g.ID)
+	writeJSON(w, http.StatusCreated, g)
+}
+
+// applyGroupSettings copies the settings in a create or update group body
+// to g, answering 400 for values GitLab does not accept
+func applyGroupSettings(w http.ResponseWriter, g *gitlab.Group, body map[string]interface{}) bool {
+
+	valid := map[string][]string{
+		"visibility":              {"private", "internal", "public"},
+		"project_creation_level":  {gitlab.ProjectCreationNoOne, gitlab.ProjectCreationMaintainer, gitlab.ProjectCreationDeveloper},
+		"subgroup_creation_level": {gitlab.SubgroupCreationOwner, gitlab.SubgroupCreationMaintainer},
+	}
+	fields := map[string]*string{
+		"description":             &g.Description,
+		"visibility":              &g.Visibility,
+		"project_creation_level":  &g.ProjectCreationLevel,
+		"subgroup_creation_level": &g.SubgroupCreationLevel,
+	}
+	for key, field := range fields {
+		v, ok := bodyString(body, key)
+		if !ok {
+			continue
+		}
+		if values, checked := valid[key]; checked && !containsString(values, v) {
+			fakeErrorText(w, http.StatusBadRequest, key+" does not have a valid value")
+			return false
+		}
+		*field = v
+	}
+
+	flags := map[string]*bool{
+		"require_two_factor_authentication": &g.RequireTwoFactorAuthentication,
+		"lfs_enabled":                       &g.LfsEnabled,
+		"request_access_enabled":            &g.RequestAccessEnabled,
+		"share_with_group_lock":             &g.ShareWithGroupLock,
+	}
+	for key, field := range flags {
+		if v, ok := bodyBool(body, key); ok {
+			*field = v
+		}
+	}
+	// nullable on Group, GitLab sends null until they are set
+	nullable := map[string]*interface{}{
+		"auto_devops_enabled": &g.AutoDevopsEnabled,
+		"emails_disabled":     &g.EmailsDisabled,
+		"mentions_disabled":   &g.MentionsDisabled,
+	}
+	for key, field := range nullable {
+		if v, ok := bodyBool(body, key); ok {
+			*field = v
+		}
+	}
+
+	if v, ok := bodyInt(body, "two_factor_grace_period"); ok {
+		g.TwoFactorGracePeriod = v
+	}
+	if v, ok := bodyInt(body, "default_branch_protection"); ok {
+		if v < 0 || v > 4 {
+			fakeErrorText(w, http.StatusBadRequest, "default_branch_protection does not have a valid value")
+			return false
+		}
+		g.DefaultBranchProtection = v
+	}
+	return true
+}
+
+func containsString(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) listSubGroups(w http.ResponseWriter, r *http.Request, args []string) {
+	g := s.groupByRef(args[0])
+	if g == nil {
+		fakeError(w, http.StatusNotFound, "404 Group Not Found")
+		return
+	}
+	children := gitlab.GroupList{}
+	for _, c := range s.groups {
+		if c.ParentID == g.ID {
+			children = append(children, c)
+		}
+	}
+	s.writeGroups(w, r, children)
+}
+
+func (s *Server) listDescendantGroups(w http.ResponseWriter, r *http.Request, args []string) {
+	g := s.groupByRef(args[0])
+	if g == nil {
+		fakeError(w, http.StatusNotFound, "404 Group Not Found")
+		return
+	}
+	s.writeGroups(w, r, s.descendants(g.ID))
+}
+
+// writeGroups filters, sorts and paginates groups
+func (s *Server) writeGroups(w http.ResponseWriter, r *http.Request, groups gitlab.GroupList) {
+
+	search := strings.ToLower(r.URL.Query().Get("search"))
+	topLevelOnly, _ := queryBool(r, "top_level_only")
+	skip := map[string]bool{}
+	for _, id := range r.URL.Query()["skip_groups[]"] {
+		skip[id] = true
+	}
+
+	gl := gitlab.GroupList{}
+	for _, g := range groups {
+		if search != "" && !strings.Contains(strings.ToLower(g.Name), search) && !strings.Contains(strings.ToLower(g.Path), search) {
+			continue
+		}
+		if topLevelOnly && g.ParentID != 0 {
+			continue
+		}
+		if skip[strconv.Itoa(g.ID)] {
+			continue
+		}
+		gl = append(gl, g)
+	}
+	ok := sortItems(w, r, gl, "id", "asc", func(i int, orderBy string) (string, bool) {
+		switch orderBy {
+		case "id":
+			return idKey(gl[i].ID), true
+		case "name":
+			return gl[i].Name, true
+		case "path":
+			return gl[i].FullPath, true
+		}
+		return "", false
+	})
+	if ok {
+		writePage(w, r, gl)
+	}
+}
+
+func (s *Server) listGroupProjects(w http.ResponseWriter, r *http.Request, args []string) {
+
+	g := s.groupByRef(args[0])
+	if g == nil {
+		fakeError(w, http.StatusNotFound, "404 Group Not Found")
+		return
+	}
+	namespaces := map[int]bool{g.ID: true}
+	if include, _ := queryBool(r, "include_subgroups"); include {
+		for _, d := range s.descendants(g.ID) {
+			namespaces[d.ID] = true
+		}
+	}
+
+	query := r.URL.Query()
+	search := strings.ToLower(query.Get("search"))
+	archived, archivedSet := queryBool(r, "archived")
+	pl := gitlab.ProjectList{}
+	for _, p := range s.projects {
+		if !namespaces[p.Namespace.ID] {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(p.Name), search) && !strings.Contains(strings.ToLower(p.Path), search) {
+			continue
+		}
+		if archivedSet && p.Archived != archived {
+			continue
+		}
+		if visibility := query.Get("visibility"); visibility != "" && p.Visibility != visibility {
+			continue
+		}
+		pl = append(pl, p)
+	}
+	ok := sortItems(w, r, pl, "id", "asc", func(i int, orderBy string) (string, bool) {
+		switch orderBy {
+		case "id":
+			return idKey(pl[i].ID), true
+		case "name":
+			return pl[i].Name, true
+		case "path":
+			return pl[i].PathWithNamespace, true
+		case "created_at":
+			return pl[i].CreatedAt.Format(time.RFC3339Nano), true
+		}
+		return "", false
+	})
+	if ok {
+		writePage(w, r, pl)
+	}
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request, args []string) {
+
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	projectPath, _ := bodyString(body, "path")
+	name, _ := bodyString(body, "name")
+	if projectPath == "" && name == "" {
+		fakeErrorText(w, http.StatusBadRequest, "name, path are missing, at least one parameter must be provided")
+		return
+	}
+	if projectPath == "" {
+		projectPath = strings.ToLower(strings.ReplaceAll(name, " ", "-"))
+	}
+
+	p := gitlab.Project{Path: projectPath, Name: name}
+	if namespaceID, ok := bodyInt(body, "namespace_id"); ok {
+		if s.group(namespaceID) == nil {
+			fakeError(w, http.StatusNotFound, "404 Namespace Not Found")
+			return
+		}
+		p.Namespace.ID = namespaceID
+	}
+	p.Visibility, _ = bodyString(body, "visibility")
+	p.DefaultBranch, _ = bodyString(body, "default_branch")
+	p.Description, _ = bodyString(body, "description")
+
+	for _, existing := range s.projects {
+		if existing.Namespace.ID == p.Namespace.ID && existing.Path == p.Path {
+			fakeError(w, http.StatusBadRequest, map[string][]string{
+				"path": {"has already been taken"},
+			})
+			return
+		}
+	}
+
+	p.ID = s.nextID()
+	s.fillProject(&p)
+	s.projects = append(s.projects, p)
+	writeJSON(w, http.StatusCreated, p)
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request, args []string) {
+	p := s.projectByRef(args[0])
+	if p == nil {
+		fakeError(w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request, args []string) {
+	p := s.projectByRef(args[0])
+	if p == nil {
+		fakeError(w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	id := p.ID
+	for i := range s.projects {
+		if s.projects[i].ID == id {
+			s.projects = append(s.projects[:i], s.projects[i+1:]...)
+			break
+		}
+	}
+	s.dropProjectState(id)
+	fakeError(w, http.StatusAccepted, "202 Accepted")
+}
+
+// memberResource resolves the "groups/*" or "projects/*" prefix of r to
+// its state key, answering 404 when it does not exist
+func (s *Server) memberResource(w http.ResponseWriter, r *http.Request, ref string) (string, int, bool) {
+	if strings.HasPrefix(r.URL.Path, "/api/v4/groups/") {
+		if g := s.groupByRef(ref); g != nil {
+			return fmt.Sprintf("groups/%d", g.ID), g.ID, true
+		}
+		fakeError(w, http.StatusNotFound, "404 Group Not Found")
+		return "", 0, false
+	}
+	if p := s.projectByRef(ref); p != nil {
+		return fmt.Sprintf("projects/%d", p.ID), p.ID, true
+	}
+	fakeError(w, http.StatusNotFound, "404 Project Not Found")
+	return "", 0, false
+}
+
+func (s *Server) listMembers(w http.ResponseWriter, r *http.Request, args []string) {
+
+	key, _, ok := s.memberResource(w, r, args[0])
+	if !ok {
+		return
+	}
+	writePage(w, r, filterMembers(r, s.members[key]))
+}
+
+func (s *Server) listAllMembers(w http.ResponseWriter, r *http.Request, args []string) {
+
+	key, id, ok := s.memberResource(w, r, args[0])
+	if !ok {
+		return
+	}
+	resource := strings.SplitN(key, "/", 2)[0]
+
+	// a user reachable several ways is listed once, with the highest level
+	all := []fakeMember{}
+	index := map[int]int{}
+	for _, m := range s.allMembers(resource, id, map[string]bool{}) {
+		if i, seen := index[m.ID]; seen {
+			if m.AccessLevel > all[i].AccessLevel {
+				all[i] = m
+			}
+			continue
+		}
+		index[m.ID] = len(all)
+		all = append(all, m)
+	}
+	writePage(w, r, filterMembers(r, all))
+}
+
+// allMembers returns the direct members of a "groups" or "projects"
+// resource, those of its ancestor groups and those of the groups it, or an
+// ancestor, is shared with, capped at the share's access level
+func (s *Server) allMembers(resource string, id int, seen map[string]bool) []fakeMember {
+
+	key := fmt.Sprintf("%s/%d", resource, id)
+	if seen[key] {
+		return nil
+	}
+	seen[key] = true
+
+	members := append([]fakeMember{}, s.members[key]...)
+	var shares []gitlab.SharedGroup
+	parentID := 0
+	if resource == "projects" {
+		if p := s.project(id); p != nil {
+			shares = p.SharedWithGroups
+			if g := s.group(p.Namespace.ID); g != nil {
+				parentID = g.ID
+			}
+		}
+	} else if g := s.group(id); g != nil {
+		shares = g.SharedWithGroups
+		parentID = g.ParentID
+	}
+	if parentID != 0 {
+		members = append(members, s.allMembers("groups", parentID, seen)...)
+	}
+	for _, share := range shares {
+		for _, m := range s.allMembers("groups", share.GroupID, seen) {
+			if m.AccessLevel > share.GroupAccessLevel {
+				m.AccessLevel = share.GroupAccessLevel
+			}
+			members = append(members, m)
+		}
+	}
+	return members
+}
+
+// filterMembers applies the query, user_ids[] and state parameters of r
+func filterMembers(r *http.Request, all []fakeMember) []fakeMember {
+
+	query := strings.ToLower(r.URL.Query().Get("query"))
+	state := r.URL.Query().Get("state")
+	userIDs := map[string]bool{}
+	for _, id := range r.URL.Query()["user_ids[]"] {
+		userIDs[id] = true
+	}
+
+	members := []fakeMember{}
+	for _, m := range all {
+		if query != "" && !strings.Contains(strings.ToLower(m.Username), query) && !strings.Contains(strings.ToLower(m.Name), query) {
+			continue
+		}
+		if len(userIDs) > 0 && !userIDs[strconv.Itoa(m.ID)] {
+			continue
+		}
+		if state != "" && m.MembershipState != state {
+			continue
+		}
+		members = append(members, m)
+	}
+	return members
+}
+
+func (s *Server) addMember(w http.ResponseWriter, r *http.Request, args []string) {
+
+	key, _, ok := s.memberResource(w, r, args[0])
+	if !ok {
+		return
+	}
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	userID, hasUser := bodyInt(body, "user_id")
+	accessLevel, hasLevel := bodyInt(body, "access_level")
+	switch {
+	case !hasUser:
+		fakeErrorText(w, http.StatusBadRequest, "user_id is missing")
+		return
+	case !hasLevel:
+		fakeErrorText(w, http.StatusBadRequest, "access_level is missing")
+		return
+	}
+	if !validAccessLevel(w, accessLevel) {
+		return
+	}
+	expiresAt, ok := bodyExpiry(w, body)
+	if !ok {
+		return
+	}
+	u := s.user(userID)
+	if u == nil {
+		fakeError(w, http.StatusNotFound, "404 User Not Found")
+		return
+	}
+	for _, m := range s.members[key] {
+		if m.ID == userID {
+			fakeError(w, http.StatusConflict, "Member already exists")
+			return
+		}
+	}
+
+	m := fakeMember{fakeUser: memberUser(*u), AccessLevel: gitlab.AccessLevel(accessLevel), ExpiresAt: expiresAt, MembershipState: "active"}
+	s.members[key] = append(s.members[key], m)
+	writeJSON(w, http.StatusCreated, m)
+}
+
+func (s *Server) updateMember(w http.ResponseWriter, r *http.Request, args []string) {
+
+	key, _, ok := s.memberResource(w, r, args[0])
+	if !ok {
+		return
+	}
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	accessLevel, hasLevel := bodyInt(body, "access_level")
+	if !hasLevel {
+		fakeErrorText(w, http.StatusBadRequest, "access_level is missing")
+		return
+	}
+	if !validAccessLevel(w, accessLevel) {
+		return
+	}
+	expiresAt, ok := bodyExpiry(w, body)
+	if !ok {
+		return
+	}
+	for i, m := range s.members[key] {
+		if strconv.Itoa(m.ID) != args[1] {
+			continue
+		}
+		m.AccessLevel = gitlab.AccessLevel(accessLevel)
+		if _, has := body["expires_at"]; has {
+			m.ExpiresAt = expiresAt
+		}
+		s.members[key][i] = m
+		writeJSON(w, http.StatusOK, m)
+		return
+	}
+	fakeError(w, http.StatusNotFound, "404 Member Not Found")
+}
+
+func (s *Server) getMember(w http.ResponseWriter, r *http.Request, args []string) {
+
+	key, _, ok := s.memberResource(w, r, args[0])
+	if !ok {
+		return
+	}
+	for _, m := range s.members[key] {
+		if strconv.Itoa(m.ID) == args[1] {
+			writeJSON(w, http.StatusOK, m)
+			return
+		}
+	}
+	fakeError(w, http.StatusNotFound, "404 Member Not Found")
+}
+
+func (s *Server) removeMember(w http.ResponseWriter, r *http.Request, args []string) {
+
+	key, _, ok := s.memberResource(w, r, args[0])
+	if !ok {
+		return
+	}
+	for i, m := range s.members[key] {
+		if strconv.Itoa(m.ID) == args[1] {
+			s.members[key] = append(s.members[key][:i:i], s.members[key][i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	fakeError(w, http.StatusNotFound, "404 Member Not Found")
+}
+
+// sharedGroups returns the shares of the "groups/*" or "projects/*" prefix
+// of r, answering 404 when it does not exist
+func (s *Server) sharedGroups(w http.ResponseWriter, r *http.Request, ref string) (*[]gitlab.SharedGroup, int, bool) {
+	key, id, ok := s.memberResource(w, r, ref)
+	if !ok {
+		return nil, 0, false
+	}
+	if strings.HasPrefix(key, "groups/") {
+		return &s.group(id).SharedWithGroups, id, true
+	}
+	return &s.project(id).SharedWithGroups, id, true
+}
+
+func (s *Server) share(w http.ResponseWriter, r *http.Request, args []string) {
+
+	shares, id, ok := s.sharedGroups(w, r, args[0])
+	if !ok {
+		return
+	}
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	groupID, hasGroup := bodyInt(body, "group_id")
+	access, hasAccess := bodyInt(body, "group_access")
+	switch {
+	case !hasGroup:
+		fakeErrorText(w, http.StatusBadRequest, "group_id is missing")
+		return
+	case !hasAccess:
+		fakeErrorText(w, http.StatusBadRequest, "group_access is missing")
+		return
+	}
+	if !validAccessLevel(w, access) {
+		return
+	}
+	expiresAt, ok := bodyExpiry(w, body)
+	if !ok {
+		return
+	}
+	g := s.group(groupID)
+	if g == nil {
+		fakeError(w, http.StatusNotFound, "404 Group Not Found")
+		return
+	}
+	for _, sg := range *shares {
+		if sg.GroupID == groupID {
+			fakeError(w, http.StatusConflict, "Shared group has already been taken")
+			return
+		}
+	}
+	sg := gitlab.SharedGroup{
+		GroupID:          g.ID,
+		GroupName:        g.Name,
+		GroupFullPath:    g.FullPath,
+		GroupAccessLevel: gitlab.AccessLevel(access),
+		ExpiresAt:        expiresAt,
+	}
+	*shares = append(*shares, sg)
+
+	// groups answer with the shared group, projects with the share link
+	if strings.HasPrefix(r.URL.Path, "/api/v4/groups/") {
+		writeJSON(w, http.StatusCreated, s.group(id))
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"id":           s.nextID(),
+		"project_id":   id,
+		"group_id":     sg.GroupID,
+		"group_access": sg.GroupAccessLevel,
+		"expires_at":   sg.ExpiresAt,
+	})
+}
+
+func (s *Server) unshare(w http.ResponseWriter, r *http.Request, args []string) {
+
+	shares, _, ok := s.sharedGroups(w, r, args[0])
+	if !ok {
+		return
+	}
+	for i, sg := range *shares {
+		if strconv.Itoa(sg.GroupID) == args[1] {
+			*shares = append((*shares)[:i:i], (*shares)[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	fakeError(w, http.StatusNotFound, "404 Not found")
+}
+
+// validAccessLevel answers 400 unless level is one GitLab accepts
+func validAccessLevel(w http.ResponseWriter, level int) bool {
+	if _, err := gitlab.ParseAccessLevel(strconv.Itoa(level)); err == nil && level != int(gitlab.NoAccess) {
+		return true
+	}
+	fakeError(w, http.StatusBadRequest, map[string][]string{
+		"access_level": {"is not included in the list"},
+	})
+	return false
+}
+
+// bodyExpiry reads an optional expires_at date, answering 400 when it is
+// malformed or in the past
+func bodyExpiry(w http.ResponseWriter, body map[string]interface{}) (*gitlab.ISODate, bool) {
+	raw, _ := bodyString(body, "expires_at")
+	if raw == "" {
+		return nil, true
+	}
+	t, err := time.Parse(dateLayout, raw)
+	if err != nil {
+		fakeErrorText(w, http.StatusBadRequest, "expires_at is invalid")
+		return nil, false
+	}
+	if t.Before(time.Now().UTC().Truncate(24 * time.Hour)) {
+		fakeError(w, http.StatusBadRequest, map[string][]string{
+			"expires_at": {"cannot be a date in the past"},
+		})
+		return nil, false
+	}
+	d := gitlab.ISODate(t)
+	return &d, true
+}
+
+func (s *Server) listVariables(w http.ResponseWriter, r *http.Request, args []string) {
+	key, _, ok := s.memberResource(w, r, args[0])
+	if !ok {
+		return
+	}
+	writePage(w, r, append(gitlab.Variables{}, s.variables[key]...))
+}
+
+func (s *Server) getVariable(w http.ResponseWriter, r *http.Request, args []string) {
+	key, _, ok := s.memberResource(w, r, args[0])
+	if !ok {
+		return
+	}
+	for _, v := range s.variables[key] {
+		if v.Key == args[1] {
+			writeJSON(w, http.StatusOK, v)
+			return
+		}
+	}
+	fakeError(w, http.StatusNotFound, "404 Variable Not Found")
+}
+
+func (s *Server) createVariable(w http.ResponseWriter, r *http.Request, args []string) {
+
+	key, _, ok := s.memberResource(w, r, args[0])
+	if !ok {
+		return
+	}
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	v := gitlab.Variable{VariableType: "env_var", EnvironmentScope: "*"}
+	v.Key, _ = bodyString(body, "key")
+	if v.Key == "" {
+		fakeError(w, http.StatusBadRequest, map[string][]string{"key": {"can't be blank"}})
+		return
+	}
+	for _, existing := range s.variables[key] {
+		if existing.Key == v.Key {
+			fakeError(w, http.StatusBadRequest, map[string][]string{"key": {"(" + v.Key + ") has already been taken"}})
+			return
+		}
+	}
+	v.Value, _ = bodyString(body, "value")
+	v.Protected, _ = bodyBool(body, "protected")
+	v.Masked, _ = bodyBool(body, "masked")
+	if scope, ok := bodyString(body, "environment_scope"); ok {
+		v.EnvironmentScope = scope
+	}
+	s.variables[key] = append(s.variables[key], v)
+	writeJSON(w, http.StatusCreated, v)
+}
+
+func (s *Server) updateVariable(w http.ResponseWriter, r *http.Request, args []string) {
+
+	key, _, ok := s.memberResource(w, r, args[0])
+	if !ok {
+		return
+	}
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	for i, v := range s.variables[key] {
+		if v.Key != args[1] {
+			continue
+		}
+		if value, ok := bodyString(body, "value"); ok {
+			v.Value = value
+		}
+		if protected, ok := bodyBool(body, "protected"); ok {
+			v.Protected = protected
+		}
+		if masked, ok := bodyBool(body, "masked"); ok {
+			v.Masked = masked
+		}
+		if scope, ok := bodyString(body, "environment_scope"); ok {
+			v.EnvironmentScope = scope
+		}
+		s.variables[key][i] = v
+		writeJSON(w, http.StatusOK, v)
+		return
+	}
+	fakeError(w, http.StatusNotFound, "404 Variable Not Found")
+}
+
+func (s *Server) listPipelines(w http.ResponseWriter, r *http.Request, args []string) {
+
+	p := s.projectByRef(args[0])
+	if p == nil {
+		fakeError(w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	query := r.URL.Query()
+	pipelines := gitlab.Pipelines{}
+	for _, pl := range s.pipelines[p.ID] {
+		if status := query.Get("status"); status != "" && pl.Status != status {
+			continue
+		}
+		if ref := query.Get("ref"); ref != "" && pl.Ref != ref {
+			continue
+		}
+		if sha := query.Get("sha"); sha != "" && pl.Sha != sha {
+			continue
+		}
+		if source := query.Get("source"); source != "" && pl.Source != source {
+			continue
+		}
+		pipelines = append(pipelines, pl)
+	}
+	// GitLab lists the newest pipelines first
+	ok := sortItems(w, r, pipelines, "id", "desc", func(i int, orderBy string) (string, bool) {
+		switch orderBy {
+		case "id":
+			return idKey(pipelines[i].ID), true
+		case "status":
+			return pipelines[i].Status, true
+		case "ref":
+			return pipelines[i].Ref, true
+		case "updated_at":
+			return pipelines[i].UpdatedAt.Format(time.RFC3339Nano), true
+		}
+		return "", false
+	})
+	if ok {
+		writePage(w, r, pipelines)
+	}
+}
+
+func (s *Server) getPipeline(w http.ResponseWriter, r *http.Request, args []string) {
+
+	p := s.projectByRef(args[0])
+	if p == nil {
+		fakeError(w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	for _, pl := range s.pipelines[p.ID] {
+		if strconv.Itoa(pl.ID) == args[1] {
+			writeJSON(w, http.StatusOK, pl)
+			return
+		}
+	}
+	fakeError(w, http.StatusNotFound, "404 Not found")
+}
+
+func (s *Server) listPipelineJobs(w http.ResponseWriter, r *http.Request, args []string) {
+
+	p := s.projectByRef(args[0])
+	if p == nil {
+		fakeError(w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	found := false
+	for _, pl := range s.pipelines[p.ID] {
+		found = found || strconv.Itoa(pl.ID) == args[1]
+	}
+	if !found {
+		fakeError(w, http.StatusNotFound, "404 Not found")
+		return
+	}
+	scopes := r.URL.Query()["scope[]"]
+	jobs := gitlab.Jobs{}
+	for _, j := range s.jobs[p.ID] {
+		if strconv.Itoa(j.Pipeline.ID) != args[1] {
+			continue
+		}
+		if len(scopes) > 0 {
+			matched := false
+			for _, scope := range scopes {
+				matched = matched || scope == j.Status
+			}
+			if !matched {
+				continue
+			}
+		}
+		jobs = append(jobs, j)
+	}
+	writePage(w, r, jobs)
+}
+
+func (s *Server) getJob(w http.ResponseWriter, r *http.Request, args []string) {
+
+	p := s.projectByRef(args[0])
+	if p == nil {
+		fakeError(w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	for _, j := range s.jobs[p.ID] {
+		if strconv.Itoa(j.ID) == args[1] {
+			writeJSON(w, http.StatusOK, j)
+			return
+		}
+	}
+	fakeError(w, http.StatusNotFound, "404 Not found")
+}
+
+func (s *Server) listProtectedBranches(w http.ResponseWriter, r *http.Request, args []string) {
+	p := s.projectByRef(args[0])
+	if p == nil {
+		fakeError(w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	writePage(w, r, append([]gitlab.ProtectedBranchSettings{}, s.protected[p.ID]...))
+}
+
+func (s *Server) protectBranch(w http.ResponseWriter, r *http.Request, args []string) {
+
+	p := s.projectByRef(args[0])
+	if p == nil {
+		fakeError(w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	var pb gitlab.ProtectedBranchSettings
+	if err := json.NewDecoder(r.Body).Decode(&pb); err != nil && err != io.EOF {
+		fakeErrorText(w, http.StatusBadRequest, "400 Bad request - body is not valid JSON")
+		return
+	}
+	if pb.Name == "" {
+		fakeErrorText(w, http.StatusBadRequest, "name is missing")
+		return
+	}
+	for _, existing := range s.protected[p.ID] {
+		if existing.Name == pb.Name {
+			fakeError(w, http.StatusConflict, fmt.Sprintf("Protected branch '%s' already exists", pb.Name))
+			return
+		}
+	}
+	pb.ID = s.nextID()
+	s.protected[p.ID] = append(s.protected[p.ID], pb)
+	writeJSON(w, http.StatusCreated, pb)
+}
+
+func (s *Server) getProtectedBranch(w http.ResponseWriter, r *http.Request, args []string) {
+	p := s.projectByRef(args[0])
+	if p == nil {
+		fakeError(w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	for _, pb := range s.protected[p.ID] {
+		if pb.Name == args[1] {
+			writeJSON(w, http.StatusOK, pb)
+			return
+		}
+	}
+	fakeError(w, http.StatusNotFound, "404 Not found")
+}
+
+func (s *Server) unprotectBranch(w http.ResponseWriter, r *http.Request, args []string) {
+	p := s.projectByRef(args[0])
+	if p == nil {
+		fakeError(w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	for i, pb := range s.protected[p.ID] {
+		if pb.Name == args[1] {
+			s.protected[p.ID] = append(s.protected[p.ID][:i], s.protected[p.ID][i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	fakeError(w, http.StatusNotFound, "404 Not found")
+}
+
+func (s *Server) listMirrors(w http.ResponseWriter, r *http.Request, args []string) {
+	p := s.projectByRef(args[0])
+	if p == nil {
+		fakeError(w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	writePage(w, r, append(gitlab.ProjectMirrors{}, s.mirrors[p.ID]...))
+}
+
+func (s *Server) createMirror(w http.ResponseWriter, r *http.Request, args []string) {
+
+	p := s.projectByRef(args[0])
+	if p == nil {
+		fakeError(w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	mirrorURL, _ := bodyString(body, "url")
+	u, err := url.Parse(mirrorURL)
+	if mirrorURL == "" || err != nil || u.Host == "" {
+		fakeError(w, http.StatusBadRequest, map[string][]string{
+			"url": {"is blocked: Only allowed schemes are http, https, ssh, git"},
+		})
+		return
+	}
+	// GitLab never returns mirror credentials
+	masked := u.String()
+	if u.User != nil {
+		u.User = nil
+		masked = strings.Replace(u.String(), "://", "://*****:*****@", 1)
+	}
+
+	m := gitlab.ProjectMirror{
+		ID:           s.nextID(),
+		URL:          masked,
+		UpdateStatus: "none",
+	}
+	m.Enabled, _ = bodyBool(body, "enabled")
+	m.OnlyProtectedBranches, _ = bodyBool(body, "only_protected_branches")
+	s.mirrors[p.ID] = append(s.mirrors[p.ID], m)
+	writeJSON(w, http.StatusCreated, m)
+}
+
+func (s *Server) updateMirror(w http.ResponseWriter, r *http.Request, args []string) {
+
+	p := s.projectByRef(args[0])
+	if p == nil {
+		fakeError(w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	for i, m := range s.mirrors[p.ID] {
+		if strconv.Itoa(m.ID) != args[1] {
+			continue
+		}
+		if enabled, ok := bodyBool(body, "enabled"); ok {
+			m.Enabled = enabled
+		}
+		if only, ok := bodyBool(body, "only_protected_branches"); ok {
+			m.OnlyProtectedBranches = only
+		}
+		s.mirrors[p.ID][i] = m
+		writeJSON(w, http.StatusOK, m)
+		return
+	}
+	fakeError(w, http.StatusNotFound, "404 Not found")
+}
+
+func (s *Server) listMergeRequests(w http.ResponseWriter, r *http.Request, args []string) {
+	p := s.projectByRef(args[0])
+	if p == nil {
+		fakeError(w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	mrs := []gitlab.MergeRequest{}
+	for _, mr := range s.mergeRequests[p.ID] {
+		if state := r.URL.Query().Get("state"); state != "" && state != "all" && mr.State != state {
+			continue
+		}
+		mrs = append(mrs, mr)
+	}
+	writePage(w, r, mrs)
+}
+
+func (s *Server) createMergeRequest(w http.ResponseWriter, r *http.Request, args []string) {
+
+	p := s.projectByRef(args[0])
+	if p == nil {
+		fakeError(w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	mr := gitlab.MergeRequest{ProjectID: p.ID, State: "opened"}
+	mr.Title, _ = bodyString(body, "title")
+	mr.Description, _ = bodyString(body, "description")
+	mr.SourceBranch, _ = bodyString(body, "source_branch")
+	mr.TargetBranch, _ = bodyString(body, "target_branch")
+	mr.Squash, _ = bodyBool(body, "squash")
+	mr.ForceRemoveSource, _ = bodyBool(body, "remove_source_branch")
+
+	for _, field := range []struct{ name, value string }{
+		{"source_branch", mr.SourceBranch},
+		{"target_branch", mr.TargetBranch},
+		{"title", mr.Title},
+	} {
+		if field.value == "" {
+			fakeErrorText(w, http.StatusBadRequest, fmt.Sprintf("%s is missing", field.name))
+			return
+		}
+	}
+	if mr.SourceBranch == mr.TargetBranch {
+		fakeError(w, http.StatusUnprocessableEntity, []string{
+			"You can't use same project/branch for source and target",
+		})
+		return
+	}
+	for _, existing := range s.mergeRequests[p.ID] {
+		if existing.State == "opened" && existing.SourceBranch == mr.SourceBranch && existing.TargetBranch == mr.TargetBranch {
+			fakeError(w, http.StatusConflict, []string{
+				fmt.Sprintf("Another open merge request already exists for this source branch: !%d", existing.IID),
+			})
+			return
+		}
+	}
+
+	mr.ID = s.nextID()
+	mr.IID = len(s.mergeRequests[p.ID]) + 1
+	mr.WebURL = fmt.Sprintf("%s/-/merge_requests/%d", s.projectURL(p.ID), mr.IID)
+	mr.CreatedAt = time.Now().UTC()
+	s.mergeRequests[p.ID] = append(s.mergeRequests[p.ID], mr)
+	writeJSON(w, http.StatusCreated, mr)
+}
+
+func (s *Server) getFile(w http.ResponseWriter, r *http.Request, args []string) {
+
+	p := s.projectByRef(args[0])
+	if p == nil {
+		fakeError(w, http.StatusNotFound, "404 Project Not Found")
+		return
+	}
+	ref := r.URL.Query().Get("ref")
+	if ref == "" {
+		fakeErrorText(w, http.StatusBadRequest, "ref is missing, ref is empty")
+		return
+	}
+	content, ok := s.files[p.ID][ref][args[1]]
+	if !ok {
+		fakeError(w, http.StatusNotFound, "404 File Not Found")
+		return
+	}
+
+	sum := sha256.Sum256(content)
+	writeJSON(w, http.StatusOK, gitlab.RepositoryFile{
+		FileName:      path.Base(args[1]),
+		FilePath:      args[1],
+		Size:          len(content),
+		Encoding:      "base64",
+		ContentSha256: fmt.Sprintf("%x", sum),
+		Ref:           ref,
+		Content:       base64.StdEncoding.EncodeToString(content),
+	})
+}
@@ -0,0 +1,969 @@
+package gitlabtest
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gitlab "github.com/maahsome/gitlab-go"
+)
+
+// Server is an in-memory GitLab API for tests.  It keeps state for
+// users, groups, projects, members, variables, pipelines, protected
+// branches, mirrors, repository files and merge requests, paginates with
+// GitLab's headers and answers with GitLab's error bodies, so the real
+// client can be exercised end to end.
+//
+//	fake := gitlabtest.NewServer()
+//	defer fake.Close()
+//	group := fake.AddGroup(gitlab.Group{Path: "platform"})
+//	client, _ := fake.Client()
+//	projects, err := client.GetGroupProjects(group.ID)
+type Server struct {
+	// Token, when set, must be sent as PRIVATE-TOKEN, JOB-TOKEN or a
+	// bearer token, otherwise requests fail with 401
+	Token string
+	// DelayedGroupDeletion makes DELETE /groups/:id mark the group for
+	// deletion, as GitLab Premium does, instead of removing it.
+	DelayedGroupDeletion bool
+
+	server *httptest.Server
+	routes []fakeRoute
+
+	mu            sync.Mutex
+	lastID        int
+	requests      int
+	methods       map[string]int
+	tokenOwner    int
+	users         gitlab.UserList
+	sshKeys       map[int]gitlab.SSHKeys
+	gpgKeys       map[int]gitlab.GPGKeys
+	groups        gitlab.GroupList
+	projects      gitlab.ProjectList
+	members       map[string][]fakeMember
+	variables     map[string]gitlab.Variables
+	pipelines     map[int]gitlab.Pipelines
+	jobs          map[int]gitlab.Jobs
+	protected     map[int][]gitlab.ProtectedBranchSettings
+	mirrors       map[int]gitlab.ProjectMirrors
+	files         map[int]map[string]map[string][]byte
+	mergeRequests map[int][]gitlab.MergeRequest
+}
+
+// fakeUser is the short user representation embedded in members
+type fakeUser struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	State    string `json:"state"`
+	WebURL   string `json:"web_url"`
+	Bot      bool   `json:"bot"`
+}
+
+type fakeMember struct {
+	fakeUser
+	AccessLevel     gitlab.AccessLevel `json:"access_level"`
+	ExpiresAt       *gitlab.ISODate    `json:"expires_at"`
+	MembershipState string             `json:"membership_state"`
+}
+
+type fakeHandler func(s *Server, w http.ResponseWriter, r *http.Request, args []string)
+
+type fakeRoute struct {
+	method  string
+	pattern []string
+	handler fakeHandler
+}
+
+// DefaultToken is the token NewServer expects
+const DefaultToken = "glpat-fake-server-token"
+
+// dateLayout is how GitLab writes ISO dates such as expires_at
+const dateLayout = "2006-01-02"
+
+// NewServer starts an empty fake GitLab, Close it when done
+func NewServer() *Server {
+
+	s := &Server{
+		Token:         DefaultToken,
+		methods:       map[string]int{},
+		sshKeys:       map[int]gitlab.SSHKeys{},
+		gpgKeys:       map[int]gitlab.GPGKeys{},
+		members:       map[string][]fakeMember{},
+		variables:     map[string]gitlab.Variables{},
+		pipelines:     map[int]gitlab.Pipelines{},
+		jobs:          map[int]gitlab.Jobs{},
+		protected:     map[int][]gitlab.ProtectedBranchSettings{},
+		mirrors:       map[int]gitlab.ProjectMirrors{},
+		files:         map[int]map[string]map[string][]byte{},
+		mergeRequests: map[int][]gitlab.MergeRequest{},
+	}
+	s.routes = fakeRoutes()
+	s.server = httptest.NewServer(s)
+	return s
+}
+
+// URL is the base URL to point a client at
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a client for the fake server authenticated with Token,
+// opts are applied after the defaults
+func (s *Server) Client(opts ...gitlab.ClientOption) (gitlab.GitlabClient, error) {
+	defaults := []gitlab.ClientOption{gitlab.WithBaseURL(s.URL()), gitlab.WithToken(s.Token)}
+	return gitlab.NewClient(append(defaults, opts...)...)
+}
+
+// AddUser adds an active user and returns its ID
+func (s *Server) AddUser(username, name string) int {
+	return s.AddUserDetails(gitlab.User{Username: username, Name: name}).ID
+}
+
+// AddUserDetails adds u, filling in the ID, state, creation time and web
+// URL when unset
+func (s *Server) AddUserDetails(u gitlab.User) gitlab.User {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if u.ID == 0 {
+		u.ID = s.nextID()
+	} else if u.ID > s.lastID {
+		s.lastID = u.ID
+	}
+	if u.State == "" {
+		u.State = "active"
+	}
+	if u.CreatedAt == nil {
+		now := time.Now().UTC()
+		u.CreatedAt = &now
+	}
+	u.WebURL = fmt.Sprintf("%s/%s", s.URL(), u.Username)
+	s.users = append(s.users, u)
+	return u
+}
+
+// AddSSHKey adds k to the SSH keys of userID, filling in the ID and
+// creation time when unset
+func (s *Server) AddSSHKey(userID int, k gitlab.SSHKey) gitlab.SSHKey {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if k.ID == 0 {
+		k.ID = s.nextID()
+	} else if k.ID > s.lastID {
+		s.lastID = k.ID
+	}
+	if k.CreatedAt.IsZero() {
+		k.CreatedAt = time.Now().UTC()
+	}
+	s.sshKeys[userID] = append(s.sshKeys[userID], k)
+	return k
+}
+
+// AddGPGKey adds k to the GPG keys of userID, filling in the ID and
+// creation time when unset
+func (s *Server) AddGPGKey(userID int, k gitlab.GPGKey) gitlab.GPGKey {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if k.ID == 0 {
+		k.ID = s.nextID()
+	} else if k.ID > s.lastID {
+		s.lastID = k.ID
+	}
+	if k.CreatedAt.IsZero() {
+		k.CreatedAt = time.Now().UTC()
+	}
+	s.gpgKeys[userID] = append(s.gpgKeys[userID], k)
+	return k
+}
+
+// UserState returns the state of userID, e.g. "active" or "blocked", empty
+// when there is no such user
+func (s *Server) UserState(userID int) string {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if u := s.user(userID); u != nil {
+		return u.State
+	}
+	return ""
+}
+
+// SetTokenOwner makes GET /user answer with the user userID, until it is
+// called /user fails with 403 as it does for CI job tokens
+func (s *Server) SetTokenOwner(userID int) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokenOwner = userID
+}
+
+// AddGroup adds g, filling in the ID, name and full path when unset.  Set
+// ParentID to nest it in an existing group.
+func (s *Server) AddGroup(g gitlab.Group) gitlab.Group {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if g.ID == 0 {
+		g.ID = s.nextID()
+	} else if g.ID > s.lastID {
+		s.lastID = g.ID
+	}
+	if g.Name == "" {
+		g.Name = g.Path
+	}
+	if g.Visibility == "" {
+		g.Visibility = "private"
+	}
+	s.fillGroup(&g)
+	if g.CreatedAt.IsZero() {
+		g.CreatedAt = time.Now().UTC()
+	}
+	s.groups = append(s.groups, g)
+	return g
+}
+
+// AddProject adds p to groupID (0 for none), filling in the ID, name and
+// paths when unset
+func (s *Server) AddProject(groupID int, p gitlab.Project) gitlab.Project {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p.Namespace.ID = groupID
+	if p.ID == 0 {
+		p.ID = s.nextID()
+	} else if p.ID > s.lastID {
+		s.lastID = p.ID
+	}
+	s.fillProject(&p)
+	s.projects = append(s.projects, p)
+	return p
+}
+
+// AddMember adds userID to a "groups" or "projects" resource
+func (s *Server) AddMember(resource string, id, userID, accessLevel int) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := fmt.Sprintf("%s/%d", resource, id)
+	m := fakeMember{AccessLevel: gitlab.AccessLevel(accessLevel), MembershipState: "active"}
+	if u := s.user(userID); u != nil {
+		m.fakeUser = memberUser(*u)
+	} else {
+		m.fakeUser = fakeUser{ID: userID, Username: fmt.Sprintf("user%d", userID), State: "active"}
+	}
+	s.members[key] = append(s.members[key], m)
+}
+
+// MemberAccessLevel returns the access level of userID on a "groups" or
+// "projects" resource, false when the user is not a direct member
+func (s *Server) MemberAccessLevel(resource string, id, userID int) (int, bool) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, m := range s.members[fmt.Sprintf("%s/%d", resource, id)] {
+		if m.ID == userID {
+			return int(m.AccessLevel), true
+		}
+	}
+	return 0, false
+}
+
+// SetVariable creates or replaces the CI/CD variable v.Key of a "groups"
+// or "projects" resource
+func (s *Server) SetVariable(resource string, id int, v gitlab.Variable) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if v.VariableType == "" {
+		v.VariableType = "env_var"
+	}
+	if v.EnvironmentScope == "" {
+		v.EnvironmentScope = "*"
+	}
+	v.Source = ""
+	key := fmt.Sprintf("%s/%d", resource, id)
+	for i := range s.variables[key] {
+		if s.variables[key][i].Key == v.Key {
+			s.variables[key][i] = v
+			return
+		}
+	}
+	s.variables[key] = append(s.variables[key], v)
+}
+
+// Variable returns the CI/CD variable key of a "groups" or "projects"
+// resource
+func (s *Server) Variable(resource string, id int, key string) (gitlab.Variable, bool) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, v := range s.variables[fmt.Sprintf("%s/%d", resource, id)] {
+		if v.Key == key {
+			return v, true
+		}
+	}
+	return gitlab.Variable{}, false
+}
+
+// AddPipeline adds p to projectID, filling in the ID and timestamps when
+// unset
+func (s *Server) AddPipeline(projectID int, p gitlab.Pipeline) gitlab.Pipeline {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p.ID == 0 {
+		p.ID = s.nextID()
+	} else if p.ID > s.lastID {
+		s.lastID = p.ID
+	}
+	p.ProjectID = projectID
+	if p.Status == "" {
+		p.Status = "success"
+	}
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = time.Now().UTC()
+	}
+	if p.UpdatedAt.IsZero() {
+		p.UpdatedAt = p.CreatedAt
+	}
+	p.WebURL = fmt.Sprintf("%s/-/pipelines/%d", s.projectURL(projectID), p.ID)
+	s.pipelines[projectID] = append(s.pipelines[projectID], p)
+	return p
+}
+
+// AddJob adds j to pipelineID of projectID, filling in the ID, status,
+// creation time, pipeline and web URL when unset
+func (s *Server) AddJob(projectID, pipelineID int, j gitlab.Job) gitlab.Job {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if j.ID == 0 {
+		j.ID = s.nextID()
+	} else if j.ID > s.lastID {
+		s.lastID = j.ID
+	}
+	if j.Status == "" {
+		j.Status = "success"
+	}
+	if j.CreatedAt.IsZero() {
+		j.CreatedAt = time.Now().UTC()
+	}
+	j.Pipeline.ID = pipelineID
+	j.Pipeline.ProjectID = projectID
+	for _, pl := range s.pipelines[projectID] {
+		if pl.ID == pipelineID {
+			j.Pipeline.Ref, j.Pipeline.Sha, j.Pipeline.Status = pl.Ref, pl.Sha, pl.Status
+			if j.Ref == "" {
+				j.Ref = pl.Ref
+			}
+		}
+	}
+	j.WebURL = fmt.Sprintf("%s/-/jobs/%d", s.projectURL(projectID), j.ID)
+	s.jobs[projectID] = append(s.jobs[projectID], j)
+	return j
+}
+
+// AddFile stores content at path on ref of projectID
+func (s *Server) AddFile(projectID int, ref, path string, content []byte) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.files[projectID] == nil {
+		s.files[projectID] = map[string]map[string][]byte{}
+	}
+	if s.files[projectID][ref] == nil {
+		s.files[projectID][ref] = map[string][]byte{}
+	}
+	s.files[projectID][ref][path] = append([]byte(nil), content...)
+}
+
+// Group returns the group with id
+func (s *Server) Group(id int) (gitlab.Group, bool) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if g := s.group(id); g != nil {
+		return *g, true
+	}
+	return gitlab.Group{}, false
+}
+
+// Project returns the project with id
+func (s *Server) Project(id int) (gitlab.Project, bool) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p := s.project(id); p != nil {
+		return *p, true
+	}
+	return gitlab.Project{}, false
+}
+
+// ProtectedBranch returns the protected branch (or pattern) name of
+// projectID
+func (s *Server) ProtectedBranch(projectID int, name string) (gitlab.ProtectedBranchSettings, bool) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, pb := range s.protected[projectID] {
+		if pb.Name == name {
+			return pb, true
+		}
+	}
+	return gitlab.ProtectedBranchSettings{}, false
+}
+
+// Mirrors returns the remote mirrors of projectID
+func (s *Server) Mirrors(projectID int) gitlab.ProjectMirrors {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append(gitlab.ProjectMirrors{}, s.mirrors[projectID]...)
+}
+
+// MergeRequests returns the merge requests of projectID
+func (s *Server) MergeRequests(projectID int) []gitlab.MergeRequest {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]gitlab.MergeRequest{}, s.mergeRequests[projectID]...)
+}
+
+// Requests returns how many requests with method the server received, or
+// how many requests of any method when method is empty
+func (s *Server) Requests(method string) int {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if method == "" {
+		return s.requests
+	}
+	return s.methods[method]
+}
+
+// ServeHTTP answers a single API request
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	s.methods[r.Method]++
+	w.Header().Set("X-Request-Id", fmt.Sprintf("fake-%06d", s.requests))
+	w.Header().Set("X-Runtime", "0.001")
+
+	if !s.authorized(r) {
+		fakeError(w, http.StatusUnauthorized, "401 Unauthorized")
+		return
+	}
+
+	const apiPath = "/api/v4/"
+	escaped := r.URL.EscapedPath()
+	if !strings.HasPrefix(escaped, apiPath) {
+		fakeErrorText(w, http.StatusNotFound, "404 Not Found")
+		return
+	}
+	var segments []string
+	for _, seg := range strings.Split(strings.Trim(escaped[len(apiPath):], "/"), "/") {
+		unescaped, err := url.PathUnescape(seg)
+		if err != nil {
+			fakeErrorText(w, http.StatusBadRequest, "400 Bad Request")
+			return
+		}
+		segments = append(segments, unescaped)
+	}
+
+	pathMatched := false
+	for _, route := range s.routes {
+		args, ok := route.match(segments)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+		if route.method == r.Method {
+			if r.Method == http.MethodGet {
+				withETag(w, r, func(w http.ResponseWriter) { route.handler(s, w, r, args) })
+				return
+			}
+			route.handler(s, w, r, args)
+			return
+		}
+	}
+	if pathMatched {
+		fakeErrorText(w, http.StatusMethodNotAllowed, "405 Method Not Allowed")
+		return
+	}
+	fakeErrorText(w, http.StatusNotFound, "404 Not Found")
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.Token == "" {
+		return true
+	}
+	return r.Header.Get("PRIVATE-TOKEN") == s.Token ||
+		r.Header.Get("JOB-TOKEN") == s.Token ||
+		r.Header.Get("Authorization") == fmt.Sprintf("Bearer %s", s.Token)
+}
+
+func (route fakeRoute) match(segments []string) ([]string, bool) {
+	if len(segments) != len(route.pattern) {
+		return nil, false
+	}
+	var args []string
+	for i, p := range route.pattern {
+		switch {
+		case p == "*":
+			args = append(args, segments[i])
+		case p != segments[i]:
+			return nil, false
+		}
+	}
+	return args, true
+}
+
+// nextID hands out IDs, s.mu must be held
+func (s *Server) nextID() int {
+	s.lastID++
+	return s.lastID
+}
+
+func (s *Server) user(id int) *gitlab.User {
+	for i := range s.users {
+		if s.users[i].ID == id {
+			return &s.users[i]
+		}
+	}
+	return nil
+}
+
+// memberUser is the part of u shown in member listings
+func memberUser(u gitlab.User) fakeUser {
+	return fakeUser{ID: u.ID, Username: u.Username, Name: u.Name, State: u.State, WebURL: u.WebURL, Bot: u.Bot}
+}
+
+func (s *Server) group(id int) *gitlab.Group {
+	for i := range s.groups {
+		if s.groups[i].ID == id {
+			return &s.groups[i]
+		}
+	}
+	return nil
+}
+
+// groupByRef finds a group by numeric ID or full path
+func (s *Server) groupByRef(ref string) *gitlab.Group {
+	if id, err := strconv.Atoi(ref); err == nil {
+		return s.group(id)
+	}
+	for i := range s.groups {
+		if s.groups[i].FullPath == ref {
+			return &s.groups[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) project(id int) *gitlab.Project {
+	for i := range s.projects {
+		if s.projects[i].ID == id {
+			return &s.projects[i]
+		}
+	}
+	return nil
+}
+
+// projectByRef finds a project by numeric ID or path with namespace
+func (s *Server) projectByRef(ref string) *gitlab.Project {
+	if id, err := strconv.Atoi(ref); err == nil {
+		return s.project(id)
+	}
+	for i := range s.projects {
+		if s.projects[i].PathWithNamespace == ref {
+			return &s.projects[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) projectURL(id int) string {
+	if p := s.project(id); p != nil {
+		return fmt.Sprintf("%s/%s", s.URL(), p.PathWithNamespace)
+	}
+	return fmt.Sprintf("%s/projects/%d", s.URL(), id)
+}
+
+// descendants returns the groups below id, depth first
+func (s *Server) descendants(id int) gitlab.GroupList {
+	gl := gitlab.GroupList{}
+	for _, g := range s.groups {
+		if g.ParentID == id {
+			gl = append(gl, g)
+			gl = append(gl, s.descendants(g.ID)...)
+		}
+	}
+	return gl
+}
+
+// fillGroup derives the full path, full name and web URL of g from its
+// parent, s.mu must be held
+func (s *Server) fillGroup(g *gitlab.Group) {
+	g.FullPath, g.FullName = g.Path, g.Name
+	if parent := s.group(g.ParentID); parent != nil {
+		g.FullPath = fmt.Sprintf("%s/%s", parent.FullPath, g.Path)
+		g.FullName = fmt.Sprintf("%s / %s", parent.FullName, g.Name)
+	}
+	g.WebURL = fmt.Sprintf("%s/groups/%s", s.URL(), g.FullPath)
+}
+
+// refreshPaths derives the paths of everything below groupID again after
+// it was renamed or transferred, s.mu must be held
+func (s *Server) refreshPaths(groupID int) {
+	for i := range s.groups {
+		if s.groups[i].ParentID == groupID {
+			s.fillGroup(&s.groups[i])
+			s.refreshPaths(s.groups[i].ID)
+		}
+	}
+	for i := range s.projects {
+		if s.projects[i].Namespace.ID == groupID {
+			s.fillProject(&s.projects[i])
+		}
+	}
+}
+
+// removeGroup drops groupID with its subgroups, projects, members,
+// variables and the shares pointing at them, s.mu must be held
+func (s *Server) removeGroup(groupID int) {
+
+	removed := map[int]bool{groupID: true}
+	for _, g := range s.descendants(groupID) {
+		removed[g.ID] = true
+	}
+	groups := gitlab.GroupList{}
+	for _, g := range s.groups {
+		if removed[g.ID] {
+			key := fmt.Sprintf("groups/%d", g.ID)
+			delete(s.members, key)
+			delete(s.variables, key)
+			continue
+		}
+		groups = append(groups, g)
+	}
+	s.groups = groups
+
+	projects := gitlab.ProjectList{}
+	for _, p := range s.projects {
+		if removed[p.Namespace.ID] {
+			s.dropProjectState(p.ID)
+			continue
+		}
+		projects = append(projects, p)
+	}
+	s.projects = projects
+
+	unshare := func(shares []gitlab.SharedGroup) []gitlab.SharedGroup {
+		kept := []gitlab.SharedGroup{}
+		for _, sg := range shares {
+			if !removed[sg.GroupID] {
+				kept = append(kept, sg)
+			}
+		}
+		return kept
+	}
+	for i := range s.groups {
+		s.groups[i].SharedWithGroups = unshare(s.groups[i].SharedWithGroups)
+	}
+	for i := range s.projects {
+		s.projects[i].SharedWithGroups = unshare(s.projects[i].SharedWithGroups)
+	}
+}
+
+// dropProjectState forgets everything kept for projectID, s.mu must be held
+func (s *Server) dropProjectState(projectID int) {
+	key := fmt.Sprintf("projects/%d", projectID)
+	delete(s.members, key)
+	delete(s.variables, key)
+	delete(s.pipelines, projectID)
+	delete(s.jobs, projectID)
+	delete(s.protected, projectID)
+	delete(s.mirrors, projectID)
+	delete(s.files, projectID)
+	delete(s.mergeRequests, projectID)
+}
+
+// fillProject derives the namespace and path fields of p, s.mu must be held
+func (s *Server) fillProject(p *gitlab.Project) {
+
+	if p.Name == "" {
+		p.Name = p.Path
+	}
+	if p.Visibility == "" {
+		p.Visibility = "private"
+	}
+	if p.DefaultBranch == "" {
+		p.DefaultBranch = "main"
+	}
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = time.Now().UTC()
+	}
+	p.PathWithNamespace, p.NameWithNamespace = p.Path, p.Name
+	if g := s.group(p.Namespace.ID); g != nil {
+		p.Namespace.Name = g.Name
+		p.Namespace.Path = g.Path
+		p.Namespace.Kind = "group"
+		p.Namespace.FullPath = g.FullPath
+		p.Namespace.ParentID = g.ParentID
+		p.Namespace.WebURL = g.WebURL
+		p.PathWithNamespace = fmt.Sprintf("%s/%s", g.FullPath, p.Path)
+		p.NameWithNamespace = fmt.Sprintf("%s / %s", g.FullName, p.Name)
+	}
+	host := s.server.Listener.Addr().(*net.TCPAddr).IP.String()
+	p.HTTPURL = fmt.Sprintf("%s/%s.git", s.URL(), p.PathWithNamespace)
+	p.SSHURL = fmt.Sprintf("git@%s:%s.git", host, p.PathWithNamespace)
+}
+
+// writePage writes the page of items (a slice) selected by the page and
+// per_page query parameters, with GitLab's pagination headers
+func writePage(w http.ResponseWriter, r *http.Request, items interface{}) {
+
+	v := reflect.ValueOf(items)
+	total := v.Len()
+
+	page := queryInt(r, "page", 1)
+	if page < 1 {
+		page = 1
+	}
+	perPage := queryInt(r, "per_page", 20)
+	if perPage < 1 {
+		perPage = 20
+	}
+	if perPage > 100 {
+		perPage = 100
+	}
+	totalPages := (total + perPage - 1) / perPage
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	start := (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+
+	header := w.Header()
+	header.Set("X-Page", strconv.Itoa(page))
+	header.Set("X-Per-Page", strconv.Itoa(perPage))
+	header.Set("X-Total", strconv.Itoa(total))
+	header.Set("X-Total-Pages", strconv.Itoa(totalPages))
+	header.Set("X-Next-Page", "")
+	header.Set("X-Prev-Page", "")
+
+	var links []string
+	link := func(p int, rel string) {
+		u := *r.URL
+		u.Scheme, u.Host = "http", r.Host
+		q := u.Query()
+		q.Set("page", strconv.Itoa(p))
+		q.Set("per_page", strconv.Itoa(perPage))
+		u.RawQuery = q.Encode()
+		links = append(links, fmt.Sprintf("<%s>; rel=\"%s\"", u.String(), rel))
+	}
+	if page > 1 {
+		header.Set("X-Prev-Page", strconv.Itoa(page-1))
+		link(page-1, "prev")
+	}
+	if page < totalPages {
+		header.Set("X-Next-Page", strconv.Itoa(page+1))
+		link(page+1, "next")
+	}
+	link(1, "first")
+	link(totalPages, "last")
+	header.Set("Link", strings.Join(links, ", "))
+
+	out := reflect.MakeSlice(v.Type(), 0, end-start)
+	out = reflect.AppendSlice(out, v.Slice(start, end))
+	writeJSON(w, http.StatusOK, out.Interface())
+}
+
+// sortItems orders slice by the order_by and sort query parameters.  key
+// returns the sort key of element i for an order_by value, false when the
+// value is not supported.
+func sortItems(w http.ResponseWriter, r *http.Request, slice interface{}, defaultOrder, defaultSort string, key func(i int, orderBy string) (string, bool)) bool {
+
+	orderBy := r.URL.Query().Get("order_by")
+	if orderBy == "" {
+		orderBy = defaultOrder
+	}
+	direction := r.URL.Query().Get("sort")
+	if direction == "" {
+		direction = defaultSort
+	}
+	if direction != "asc" && direction != "desc" {
+		fakeErrorText(w, http.StatusBadRequest, "sort does not have a valid value")
+		return false
+	}
+	if reflect.ValueOf(slice).Len() > 0 {
+		if _, ok := key(0, orderBy); !ok {
+			fakeErrorText(w, http.StatusBadRequest, "order_by does not have a valid value")
+			return false
+		}
+	}
+
+	sort.SliceStable(slice, func(i, j int) bool {
+		a, _ := key(i, orderBy)
+		b, _ := key(j, orderBy)
+		if direction == "desc" {
+			return a > b
+		}
+		return a < b
+	})
+	return true
+}
+
+// idKey formats an ID so it sorts as a string
+func idKey(id int) string {
+	return fmt.Sprintf("%012d", id)
+}
+
+func queryInt(r *http.Request, key string, def int) int {
+	v, err := strconv.Atoi(r.URL.Query().Get(key))
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// queryBool returns the boolean query parameter key and whether it was set
+func queryBool(r *http.Request, key string) (bool, bool) {
+	v, err := strconv.ParseBool(r.URL.Query().Get(key))
+	if err != nil {
+		return false, false
+	}
+	return v, true
+}
+
+// withETag runs a GET handler, tagging a 200 response with a weak ETag of
+// its body and answering 304 when it matches If-None-Match, as GitLab does
+func withETag(w http.ResponseWriter, r *http.Request, handler func(http.ResponseWriter)) {
+
+	rec := httptest.NewRecorder()
+	handler(rec)
+
+	header := w.Header()
+	for k, v := range rec.Header() {
+		header[k] = v
+	}
+	if rec.Code == http.StatusOK {
+		sum := sha256.Sum256(rec.Body.Bytes())
+		etag := fmt.Sprintf(`W/"%x"`, sum[:16])
+		header.Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	w.WriteHeader(rec.Code)
+	_, _ = w.Write(rec.Body.Bytes())
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// fakeError answers with GitLab's {"message": ...} error body, message may
+// be a string, a list or a field -> errors map
+func fakeError(w http.ResponseWriter, status int, message interface{}) {
+	writeJSON(w, status, map[string]interface{}{"message": message})
+}
+
+// fakeErrorText answers with GitLab's {"error": "..."} error body, used for
+// routing and parameter validation failures
+func fakeErrorText(w http.ResponseWriter, status int, text string) {
+	writeJSON(w, status, map[string]string{"error": text})
+}
+
+// readBody decodes a JSON request body into a generic map, answering 400
+// when it is not valid JSON
+func readBody(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	body := map[string]interface{}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err != io.EOF {
+		fakeErrorText(w, http.StatusBadRequest, "400 Bad request - body is not valid JSON")
+		return nil, false
+	}
+	return body, true
+}
+
+// bodyString returns a string field, false when missing
+func bodyString(body map[string]interface{}, key string) (string, bool) {
+	v, ok := body[key].(string)
+	return v, ok
+}
+
+// bodyInt returns a numeric field, which GitLab also accepts as a string
+func bodyInt(body map[string]interface{}, key string) (int, bool) {
+	switch v := body[key].(type) {
+	case float64:
+		return int(v), true
+	case string:
+		i, err := strconv.Atoi(v)
+		return i, err == nil
+	}
+	return 0, false
+}
+
+// bodyBool returns a boolean field, which GitLab also accepts as a string
+func bodyBool(body map[string]interface{}, key string) (bool, bool) {
+	switch v := body[key].(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	}
+	return false, false
+}
@@ -0,0 +1,19 @@
+package gitlab_test
+
+import (
+	"testing"
+
+	gitlab "github.com/maahsome/gitlab-go"
+	"github.com/maahsome/gitlab-go/gitlabtest"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeClient starts a gitlabtest.Server and a quiet client for it
+func newFakeClient(t *testing.T, opts ...gitlab.ClientOption) (*gitlabtest.Server, gitlab.GitlabClient) {
+	t.Helper()
+	fake := gitlabtest.NewServer()
+	t.Cleanup(fake.Close)
+	client, err := fake.Client(append([]gitlab.ClientOption{gitlab.WithLogger(gitlab.NopLogger())}, opts...)...)
+	require.NoError(t, err)
+	return fake, client
+}
@@ -0,0 +1,276 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tracer starts a span for every HTTP request the client sends, retries
+// included.  An OpenTelemetry tracer adapts in a few lines:
+//
+//	type otelTracer struct{ trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, gitlab.Span) {
+//		ctx, span := t.Tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+//		return ctx, otelSpan{span}
+//	}
+//
+// The context returned by Start is used for the request, so trace headers
+// can be injected further down the transport chain.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a single traced request.  Attributes set by the client are
+// "http.method", "gitlab.endpoint", "http.status_code" and, for paginated
+// requests, "gitlab.page".
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// Metrics records one observation per HTTP request the client sends.  A
+// Prometheus implementation would increment a counter and observe a
+// histogram labelled with endpoint, method and status class:
+//
+//	func (m promMetrics) ObserveRequest(endpoint, method, statusClass string, d time.Duration) {
+//		m.requests.WithLabelValues(endpoint, method, statusClass).Inc()
+//		m.latency.WithLabelValues(endpoint, method, statusClass).Observe(d.Seconds())
+//	}
+//
+// statusClass is "2xx", "3xx", "4xx", "5xx", or "error" when no response
+// was received.
+type Metrics interface {
+	ObserveRequest(endpoint, method, statusClass string, duration time.Duration)
+}
+
+// WithTracer creates a span per request through t, named after the method
+// and endpoint template, e.g. "GET /projects/:id/pipelines"
+func WithTracer(t Tracer) ClientOption {
+	return func(c *clientConfig) error {
+		c.tracer = t
+		return nil
+	}
+}
+
+// WithMetrics reports the endpoint, status class and latency of each request
+// to m
+func WithMetrics(m Metrics) ClientOption {
+	return func(c *clientConfig) error {
+		c.metrics = m
+		return nil
+	}
+}
+
+// instrumentedTransport traces and measures each request sent through next
+type instrumentedTransport struct {
+	next    http.RoundTripper
+	apiPath string
+	tracer  Tracer
+	metrics Metrics
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	endpoint := endpointTemplate(req.URL.EscapedPath(), t.apiPath)
+
+	var span Span
+	if t.tracer != nil {
+		var ctx context.Context
+		ctx, span = t.tracer.Start(req.Context(), req.Method+" "+endpoint)
+		defer span.End()
+		span.SetAttribute("http.method", req.Method)
+		span.SetAttribute("gitlab.endpoint", endpoint)
+		if page, err := strconv.Atoi(req.URL.Query().Get("page")); err == nil {
+			span.SetAttribute("gitlab.page", page)
+		}
+		req = req.WithContext(ctx)
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	statusClass := "error"
+	if err == nil {
+		statusClass = fmt.Sprintf("%dxx", resp.StatusCode/100)
+	}
+	if span != nil {
+		if err != nil {
+			span.RecordError(err)
+		} else {
+			span.SetAttribute("http.status_code", resp.StatusCode)
+			if page, perr := strconv.Atoi(resp.Header.Get("X-Page")); perr == nil {
+				span.SetAttribute("gitlab.page", page)
+			}
+		}
+	}
+	if t.metrics != nil {
+		t.metrics.ObserveRequest(endpoint, req.Method, statusClass, elapsed)
+	}
+	return resp, err
+}
+
+// endpointRoutes are the endpoint templates spans and metrics are named
+// after.  A ":param" segment matches any value, except that ID parameters
+// (see endpointIDParams) only match numeric or URL-escaped path segments,
+// so "/projects/42/pipelines/latest" keeps its "latest".
+var endpointRoutes = []string{
+	"/application/settings",
+	"/user",
+	"/users",
+	"/users/:id",
+	"/users/:id/keys",
+	"/users/:id/gpg_keys",
+	"/users/:id/memberships",
+	"/users/:id/block",
+	"/users/:id/unblock",
+	"/users/:id/deactivate",
+	"/users/:id/activate",
+	"/groups",
+	"/groups/:id",
+	"/groups/:id/subgroups",
+	"/groups/:id/descendant_groups",
+	"/groups/:id/projects",
+	"/groups/:id/members",
+	"/groups/:id/members/all",
+	"/groups/:id/members/:user_id",
+	"/groups/:id/variables",
+	"/groups/:id/variables/:key",
+	"/groups/:id/share",
+	"/groups/:id/share/:group_id",
+	"/groups/:id/restore",
+	"/groups/:id/transfer",
+	"/projects",
+	"/projects/:id",
+	"/projects/:id/members",
+	"/projects/:id/members/all",
+	"/projects/:id/members/:user_id",
+	"/projects/:id/variables",
+	"/projects/:id/variables/:key",
+	"/projects/:id/share",
+	"/projects/:id/share/:group_id",
+	"/projects/:id/hooks",
+	"/projects/:id/hooks/:hook_id",
+	"/projects/:id/pipelines",
+	"/projects/:id/pipelines/latest",
+	"/projects/:id/pipelines/:pipeline_id",
+	"/projects/:id/pipelines/:pipeline_id/jobs",
+	"/projects/:id/jobs",
+	"/projects/:id/jobs/:job_id",
+	"/projects/:id/merge_requests",
+	"/projects/:id/merge_requests/:merge_request_iid",
+	"/projects/:id/protected_branches",
+	"/projects/:id/protected_branches/:name",
+	"/projects/:id/remote_mirrors",
+	"/projects/:id/remote_mirrors/:mirror_id",
+	"/projects/:id/repository/branches",
+	"/projects/:id/repository/branches/:branch",
+	"/projects/:id/repository/files/:file_path",
+}
+
+// endpointIDParams are the route parameters holding an ID, or for :id a
+// URL-escaped namespaced path
+var endpointIDParams = map[string]bool{
+	":id":                true,
+	":user_id":           true,
+	":group_id":          true,
+	":hook_id":           true,
+	":pipeline_id":       true,
+	":job_id":            true,
+	":merge_request_iid": true,
+	":mirror_id":         true,
+}
+
+// endpointOther is the label of paths missing from endpointRoutes, below
+// "/projects/:id" and the other endpointResources when under one of them
+const endpointOther = "other"
+
+// endpointTemplate turns a request path into a low cardinality template
+// relative to apiPath, "/api/v4/projects/42/pipelines" into
+// "/projects/:id/pipelines".  Paths missing from endpointRoutes all become
+// "/projects/:id/other", "/groups/:id/other", ... or "/other", their
+// segments may be SHAs, tag names or any other unbounded value.
+func endpointTemplate(path, apiPath string) string {
+
+	segments := strings.Split(strings.Trim(apiRelativePath(path, apiPath), "/"), "/")
+
+	best, bestLiterals := "", -1
+	for _, route := range endpointRoutes {
+		if literals, ok := matchRoute(route, segments); ok && literals > bestLiterals {
+			best, bestLiterals = route, literals
+		}
+	}
+	if best != "" {
+		return best
+	}
+
+	if len(segments) > 1 && endpointResources[segments[0]] {
+		return "/" + segments[0] + "/:id/" + endpointOther
+	}
+	return "/" + endpointOther
+}
+
+// matchRoute reports whether segments fit route and how many of the route's
+// segments are literals, the most literal match wins
+func matchRoute(route string, segments []string) (int, bool) {
+
+	parts := strings.Split(strings.Trim(route, "/"), "/")
+	if len(parts) != len(segments) {
+		return 0, false
+	}
+	literals := 0
+	for i, part := range parts {
+		switch {
+		case !strings.HasPrefix(part, ":"):
+			if part != segments[i] {
+				return 0, false
+			}
+			literals++
+		case isResourceID(segments, i):
+		case endpointIDParams[part] && !isIDSegment(segments[i]):
+			return 0, false
+		}
+	}
+	return literals, true
+}
+
+// endpointResources are the top level resources whose :id may be a path as
+// well as a number, "/groups/platform" being group "platform"
+var endpointResources = map[string]bool{
+	"groups":   true,
+	"projects": true,
+	"users":    true,
+}
+
+// isResourceID reports whether segments[i] is the :id right after one of
+// endpointResources, which is an ID whatever it looks like
+func isResourceID(segments []string, i int) bool {
+	return i == 1 && endpointResources[segments[0]]
+}
+
+// isIDSegment reports whether segment is numeric or a URL-escaped
+// namespaced path such as "group%2Fproject"
+func isIDSegment(segment string) bool {
+	if _, err := strconv.Atoi(segment); err == nil {
+		return true
+	}
+	return strings.Contains(segment, "%2F") || strings.Contains(segment, "%2f")
+}
+
+// apiRelativePath strips everything up to and including apiPath from path
+func apiRelativePath(path, apiPath string) string {
+	apiPath = strings.TrimSuffix(apiPath, "/")
+	if apiPath == "" {
+		return path
+	}
+	if i := strings.Index(path, apiPath+"/"); i >= 0 {
+		return path[i+len(apiPath):]
+	}
+	return path
+}
@@ -0,0 +1,36 @@
+package gitlab
+
+import "testing"
+
+func TestEndpointTemplate(t *testing.T) {
+
+	for path, want := range map[string]string{
+		"/api/v4/projects/42/pipelines":                                   "/projects/:id/pipelines",
+		"/api/v4/projects/42/pipelines/latest":                            "/projects/:id/pipelines/latest",
+		"/api/v4/projects/42/pipelines/7/jobs":                            "/projects/:id/pipelines/:pipeline_id/jobs",
+		"/api/v4/projects/grp%2Fsub%2Fsite":                               "/projects/:id",
+		"/api/v4/projects/grp%2Fsite/repository/files/%2Egitlab-ci%2Eyml": "/projects/:id/repository/files/:file_path",
+		"/api/v4/projects/42/protected_branches/main":                     "/projects/:id/protected_branches/:name",
+		"/api/v4/projects/42/variables/DEPLOY_TOKEN":                      "/projects/:id/variables/:key",
+		"/api/v4/groups/3/members/all":                                    "/groups/:id/members/all",
+		"/api/v4/groups/3/members/9":                                      "/groups/:id/members/:user_id",
+		"/api/v4/users/5/block":                                           "/users/:id/block",
+		"/api/v4/application/settings":                                    "/application/settings",
+		"/gitlab/api/v4/groups/3/share/4":                                 "/groups/:id/share/:group_id",
+		"/api/v4/projects/42/releases/latest/assets/3":                    "/projects/:id/other",
+		"/api/v4/projects/42/releases/v1%2E0":                             "/projects/:id/other",
+		"/api/v4/projects/42/members/latest":                              "/projects/:id/other",
+		"/api/v4/groups/platform":                                         "/groups/:id",
+		"/api/v4/groups/my%2Egroup/members":                               "/groups/:id/members",
+		"/api/v4/projects/site/unknown":                                   "/projects/:id/other",
+		"/api/v4/users/alice":                                             "/users/:id",
+		"/api/v4/projects/42/repository/commits/0a1b2c3d/statuses":        "/projects/:id/other",
+		"/api/v4/groups/3/epics/12":                                       "/groups/:id/other",
+		"/api/v4/version":                                                 "/other",
+		"/api/v4/snippets/9f3e/raw":                                       "/other",
+	} {
+		if got := endpointTemplate(path, "/api/v4"); got != want {
+			t.Errorf("endpointTemplate(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,399 @@
+// mockgen writes testify mocks of interfaces of the gitlab package,
+// GitlabMock for GitlabClient by default.
+//
+//	go generate ./...                 # rewrite mock_gitlab.go and mock_services.go
+//	go run ./internal/mockgen -check  # fail when mock_gitlab.go is stale
+//
+// -interface takes a comma separated list, each mock is named after its
+// interface without the Client or Service suffix: UsersService -> UsersMock.
+// A mock also has the methods of the interfaces its accessors return, like
+// GitlabClient.Users, and returns itself from them.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "mockgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run generates, or with -check verifies, the mocks asked for by args
+func run(args []string) error {
+
+	flags := flag.NewFlagSet("mockgen", flag.ContinueOnError)
+	source := flags.String("dir", ".", "package directory declaring the interface")
+	ifaces := flags.String("interface", "GitlabClient", "comma separated interfaces to mock")
+	mockName := flags.String("name", "", "name of the mock type, only with a single interface")
+	out := flags.String("out", "mock_gitlab.go", "file to write")
+	check := flags.Bool("check", false, "only report whether out is up to date")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	names := strings.Split(*ifaces, ",")
+	var mockNames []string
+	for _, name := range names {
+		mockNames = append(mockNames, defaultMockName(name))
+	}
+	if *mockName != "" {
+		if len(names) != 1 {
+			return fmt.Errorf("-name needs a single -interface")
+		}
+		mockNames[0] = *mockName
+	}
+
+	generated, err := generate(*source, names, mockNames)
+	if err != nil {
+		return err
+	}
+
+	if *check {
+		current, err := os.ReadFile(*out)
+		if err != nil || !bytes.Equal(current, generated) {
+			return fmt.Errorf("%s is out of date with %s, run go generate", *out, *ifaces)
+		}
+		return nil
+	}
+
+	return os.WriteFile(*out, generated, 0644)
+}
+
+// defaultMockName turns GitlabClient into GitlabMock and UsersService into
+// UsersMock
+func defaultMockName(iface string) string {
+	for _, suffix := range []string{"Client", "Service"} {
+		iface = strings.TrimSuffix(iface, suffix)
+	}
+	return iface + "Mock"
+}
+
+func generate(dir string, ifaceNames, mockNames []string) ([]byte, error) {
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected one package in %s, found %d", dir, len(pkgs))
+	}
+
+	var pkgName string
+	interfaces := map[string]*ast.InterfaceType{}
+	imports := map[string]string{}
+	for name, pkg := range pkgs {
+		pkgName = name
+		for _, file := range pkg.Files {
+			collectInterfaces(file, interfaces)
+			for _, spec := range file.Imports {
+				path, _ := strconv.Unquote(spec.Path.Value)
+				name := path[strings.LastIndex(path, "/")+1:]
+				if spec.Name != nil {
+					name = spec.Name.Name
+				}
+				imports[name] = path
+			}
+		}
+	}
+
+	g := &generator{fset: fset, imports: imports, used: map[string]bool{}, interfaces: interfaces}
+	var body bytes.Buffer
+	for i, ifaceName := range ifaceNames {
+		if err := g.mock(&body, ifaceName, mockNames[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by internal/mockgen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+	var std []string
+	for name := range g.used {
+		std = append(std, strconv.Quote(imports[name]))
+	}
+	sort.Strings(std)
+	buf.WriteString("import (\n")
+	for _, p := range std {
+		fmt.Fprintf(&buf, "\t%s\n", p)
+	}
+	buf.WriteString("\n\t\"github.com/stretchr/testify/mock\"\n)\n\n")
+	buf.Write(body.Bytes())
+
+	return format.Source(buf.Bytes())
+}
+
+// mock writes the mock type mockName and its methods
+func (g *generator) mock(w *bytes.Buffer, ifaceName, mockName string) error {
+
+	methods, err := g.methodSet(ifaceName, map[string]bool{})
+	if err != nil {
+		return err
+	}
+	methods = append(methods, g.accessedMethods(methods)...)
+	sort.SliceStable(methods, func(i, j int) bool { return methods[i].name < methods[j].name })
+	implemented := map[string]bool{}
+	for _, m := range methods {
+		implemented[m.name] = true
+	}
+
+	self := g.selfAccessors(methods, implemented)
+
+	fmt.Fprintf(w, "// %s is a testify mock of %s, every method goes through mock.Called\n", mockName, ifaceName)
+	w.WriteString("//\n")
+	fmt.Fprintf(w, "//\tm := &gitlab.%s{}\n", mockName)
+	fmt.Fprintf(w, "//\tm.On(\"%s\", args...).Return(results...)\n", methods[0].name)
+	w.WriteString("//\t...\n")
+	w.WriteString("//\tm.AssertExpectations(t)\n")
+	w.WriteString("//\n")
+	w.WriteString("// Return values may also be functions taking the method's arguments.\n")
+	if len(self) > 0 {
+		w.WriteString("// Service accessors return the mock itself.\n")
+	}
+	fmt.Fprintf(w, "type %s struct {\n\tmock.Mock\n}\n\n", mockName)
+	fmt.Fprintf(w, "var _ %s = (*%s)(nil)\n\n", ifaceName, mockName)
+
+	for i, m := range methods {
+		if i > 0 && methods[i-1].name == m.name {
+			continue
+		}
+		if result, ok := self[m.name]; ok {
+			fmt.Fprintf(w, "// %s - returns the mock itself\n", m.name)
+			fmt.Fprintf(w, "func (m *%s) %s() %s {\n\treturn m\n}\n\n", mockName, m.name, result)
+			continue
+		}
+		owner := ifaceName
+		if m.owner != "" {
+			owner = m.owner
+		}
+		if err := g.method(w, owner, mockName, m.name, m.fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// accessedMethods lists the methods of the interfaces returned by the
+// accessors among methods, like GitlabClient.Projects, so the mock can
+// return itself from them
+func (g *generator) accessedMethods(methods []interfaceMethod) []interfaceMethod {
+
+	var accessed []interfaceMethod
+	for _, m := range methods {
+		result, ok := g.accessorResult(m)
+		if !ok {
+			continue
+		}
+		resultMethods, err := g.methodSet(result, map[string]bool{})
+		if err != nil {
+			continue
+		}
+		for _, rm := range resultMethods {
+			if rm.owner == "" {
+				rm.owner = result
+			}
+			accessed = append(accessed, rm)
+		}
+	}
+	return accessed
+}
+
+// accessorResult returns the interface m returns when m has no parameters
+// and a single result that is an interface of the package
+func (g *generator) accessorResult(m interfaceMethod) (string, bool) {
+
+	if len(m.fn.Params.List) != 0 || m.fn.Results == nil || len(m.fn.Results.List) != 1 {
+		return "", false
+	}
+	ident, ok := m.fn.Results.List[0].Type.(*ast.Ident)
+	if !ok || g.interfaces[ident.Name] == nil {
+		return "", false
+	}
+	return ident.Name, true
+}
+
+// selfAccessors finds the methods without parameters returning a single
+// interface of the package that the mock implements as well, like
+// GitlabClient.Projects, keyed by name with the result type as value
+func (g *generator) selfAccessors(methods []interfaceMethod, implemented map[string]bool) map[string]string {
+
+	var self map[string]string
+	for _, m := range methods {
+		result, ok := g.accessorResult(m)
+		if !ok {
+			continue
+		}
+		resultMethods, err := g.methodSet(result, map[string]bool{})
+		if err != nil {
+			continue
+		}
+		all := true
+		for _, rm := range resultMethods {
+			all = all && implemented[rm.name]
+		}
+		if all {
+			if self == nil {
+				self = map[string]string{}
+			}
+			self[m.name] = result
+		}
+	}
+	return self
+}
+
+func collectInterfaces(file *ast.File, interfaces map[string]*ast.InterfaceType) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if it, ok := ts.Type.(*ast.InterfaceType); ok {
+				interfaces[ts.Name.Name] = it
+			}
+		}
+	}
+}
+
+type interfaceMethod struct {
+	name string
+	fn   *ast.FuncType
+	// owner is the interface an accessor returns the method from, empty
+	// for the mocked interface's own methods
+	owner string
+}
+
+// methodSet lists the methods of interface name, including those of the
+// interfaces it embeds from the same package
+func (g *generator) methodSet(name string, seen map[string]bool) ([]interfaceMethod, error) {
+
+	iface, ok := g.interfaces[name]
+	if !ok {
+		return nil, fmt.Errorf("interface %s not found", name)
+	}
+	if seen[name] {
+		return nil, nil
+	}
+	seen[name] = true
+
+	var methods []interfaceMethod
+	for _, field := range iface.Methods.List {
+		switch t := field.Type.(type) {
+		case *ast.FuncType:
+			methods = append(methods, interfaceMethod{name: field.Names[0].Name, fn: t})
+		case *ast.Ident:
+			embedded, err := g.methodSet(t.Name, seen)
+			if err != nil {
+				return nil, err
+			}
+			methods = append(methods, embedded...)
+		default:
+			return nil, fmt.Errorf("%s: unsupported embedded type %s", name, g.typeString(field.Type))
+		}
+	}
+	return methods, nil
+}
+
+type generator struct {
+	fset       *token.FileSet
+	imports    map[string]string
+	used       map[string]bool
+	interfaces map[string]*ast.InterfaceType
+}
+
+// typeString prints expr and records the packages it refers to
+func (g *generator) typeString(expr ast.Expr) string {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok {
+				if _, known := g.imports[pkg.Name]; known {
+					g.used[pkg.Name] = true
+				}
+			}
+		}
+		return true
+	})
+	var buf bytes.Buffer
+	_ = format.Node(&buf, g.fset, expr)
+	return buf.String()
+}
+
+func (g *generator) method(w *bytes.Buffer, ifaceName, mockName, name string, fn *ast.FuncType) error {
+
+	var params, args, paramTypes []string
+	for _, field := range fn.Params.List {
+		typ := g.typeString(field.Type)
+		if _, variadic := field.Type.(*ast.Ellipsis); variadic {
+			return fmt.Errorf("%s: variadic parameters are not supported", name)
+		}
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("_a%d", len(args)))}
+		}
+		for _, n := range names {
+			params = append(params, fmt.Sprintf("%s %s", n.Name, typ))
+			args = append(args, n.Name)
+			paramTypes = append(paramTypes, typ)
+		}
+	}
+
+	var results []string
+	if fn.Results != nil {
+		for _, field := range fn.Results.List {
+			typ := g.typeString(field.Type)
+			count := len(field.Names)
+			if count == 0 {
+				count = 1
+			}
+			for i := 0; i < count; i++ {
+				results = append(results, typ)
+			}
+		}
+	}
+
+	resultList := strings.Join(results, ", ")
+	if len(results) > 1 {
+		resultList = fmt.Sprintf("(%s)", resultList)
+	}
+	argList := strings.Join(args, ", ")
+	funcSig := fmt.Sprintf("func(%s)", strings.Join(paramTypes, ", "))
+
+	fmt.Fprintf(w, "// %s - mock of %s.%s\n", name, ifaceName, name)
+	fmt.Fprintf(w, "func (m *%s) %s(%s) %s {\n", mockName, name, strings.Join(params, ", "), resultList)
+	if len(results) == 0 {
+		fmt.Fprintf(w, "\tm.Called(%s)\n}\n\n", argList)
+		return nil
+	}
+
+	fmt.Fprintf(w, "\tret := m.Called(%s)\n\n", argList)
+	var returns []string
+	for i, typ := range results {
+		r := fmt.Sprintf("r%d", i)
+		returns = append(returns, r)
+		if typ == "error" {
+			fmt.Fprintf(w, "\tvar %s error\n", r)
+			fmt.Fprintf(w, "\tif rf, ok := ret.Get(%d).(%s error); ok {\n\t\t%s = rf(%s)\n\t} else {\n\t\t%s = ret.Error(%d)\n\t}\n\n", i, funcSig, r, argList, r, i)
+			continue
+		}
+		fmt.Fprintf(w, "\tvar %s %s\n", r, typ)
+		fmt.Fprintf(w, "\tif rf, ok := ret.Get(%d).(%s %s); ok {\n\t\t%s = rf(%s)\n\t} else if ret.Get(%d) != nil {\n\t\t%s = ret.Get(%d).(%s)\n\t}\n\n", i, funcSig, typ, r, argList, i, r, i, typ)
+	}
+	fmt.Fprintf(w, "\treturn %s\n}\n\n", strings.Join(returns, ", "))
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// packageDir is the gitlab package, relative to this test
+const packageDir = "../.."
+
+// TestMocksUpToDate runs every mockgen go:generate directive of the package
+// in check mode, so a changed interface fails the build until the mocks are
+// regenerated
+func TestMocksUpToDate(t *testing.T) {
+
+	directives := generateDirectives(t)
+	if len(directives) == 0 {
+		t.Fatal("no mockgen go:generate directives found")
+	}
+	for _, args := range directives {
+		args := withPackageDir(args)
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			if err := run(append(args, "-check")); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// generateDirectives returns the arguments of every
+// "//go:generate go run ./internal/mockgen" line in the package
+func generateDirectives(t *testing.T) [][]string {
+
+	const prefix = "//go:generate go run ./internal/mockgen"
+	files, err := filepath.Glob(filepath.Join(packageDir, "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var directives [][]string
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == prefix || strings.HasPrefix(line, prefix+" ") {
+				directives = append(directives, strings.Fields(strings.TrimPrefix(line, prefix)))
+			}
+		}
+		f.Close()
+		if err := scanner.Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return directives
+}
+
+// withPackageDir points -dir and -out, which go generate resolves in the
+// package directory, at packageDir
+func withPackageDir(args []string) []string {
+
+	resolved := []string{"-dir", packageDir}
+	out := "mock_gitlab.go"
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-out":
+			if i+1 < len(args) {
+				out = args[i+1]
+				i++
+			}
+		case "-dir":
+			i++
+		default:
+			resolved = append(resolved, args[i])
+		}
+	}
+	return append(resolved, "-out", filepath.Join(packageDir, out))
+}
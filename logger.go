@@ -0,0 +1,163 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// Logger receives the client's log messages.  kv holds alternating keys and
+// values, in the style of log/slog.  Messages never carry tokens, request or
+// response bodies.
+type Logger interface {
+	Debug(msg string, kv ...interface{})
+	Info(msg string, kv ...interface{})
+	Warn(msg string, kv ...interface{})
+	Error(msg string, kv ...interface{})
+}
+
+// NewLogrusLogger logs to entry, or to the standard logrus logger when entry
+// is nil
+func NewLogrusLogger(entry *logrus.Entry) Logger {
+	if entry == nil {
+		entry = logrus.NewEntry(logrus.StandardLogger())
+	}
+	return logrusLogger{entry: entry}
+}
+
+type logrusLogger struct {
+	entry *logrus.Entry
+}
+
+func (l logrusLogger) with(kv []interface{}) *logrus.Entry {
+	if len(kv) == 0 {
+		return l.entry
+	}
+	fields := logrus.Fields{}
+	for i := 0; i < len(kv); i += 2 {
+		key := fmt.Sprint(kv[i])
+		if i+1 < len(kv) {
+			fields[key] = kv[i+1]
+		} else {
+			fields[key] = nil
+		}
+	}
+	return l.entry.WithFields(fields)
+}
+
+func (l logrusLogger) Debug(msg string, kv ...interface{}) { l.with(kv).Debug(msg) }
+func (l logrusLogger) Info(msg string, kv ...interface{})  { l.with(kv).Info(msg) }
+func (l logrusLogger) Warn(msg string, kv ...interface{})  { l.with(kv).Warn(msg) }
+func (l logrusLogger) Error(msg string, kv ...interface{}) { l.with(kv).Error(msg) }
+
+// NopLogger discards every message
+func NopLogger() Logger {
+	return nopLogger{}
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+type loggerHolder struct{ Logger }
+
+var defaultLogger atomic.Value
+
+func init() {
+	defaultLogger.Store(loggerHolder{NewLogrusLogger(nil)})
+}
+
+// SetDefaultLogger replaces the logger used by clients built without
+// WithLogger and by the ToJSON/ToYAML/ToGRON formatters.  It starts out as
+// the standard logrus logger.
+func SetDefaultLogger(l Logger) {
+	if l == nil {
+		l = NopLogger()
+	}
+	defaultLogger.Store(loggerHolder{l})
+}
+
+func packageLogger() Logger {
+	return defaultLogger.Load().(loggerHolder).Logger
+}
+
+// WithLogger sends the client's log messages to l instead of the default
+// logger, pass NopLogger() to silence the client
+func WithLogger(l Logger) ClientOption {
+	return func(c *clientConfig) error {
+		if l == nil {
+			l = NopLogger()
+		}
+		c.logger = l
+		return nil
+	}
+}
+
+// logResponse logs every response at debug level
+func logResponse(l Logger) resty.ResponseMiddleware {
+	return func(c *resty.Client, resp *resty.Response) error {
+		if resp.Request == nil {
+			return nil
+		}
+		l.Debug("gitlab request",
+			"method", resp.Request.Method,
+			"path", logPath(resp.Request.URL),
+			"status", resp.StatusCode(),
+			"duration", resp.Time().Round(time.Millisecond),
+			"request_id", resp.Header().Get("X-Request-Id"),
+		)
+		return nil
+	}
+}
+
+// logError logs requests that failed without a response at debug level,
+// the error itself is returned to the caller
+func logError(l Logger) resty.ErrorHook {
+	return func(req *resty.Request, err error) {
+		if re, ok := err.(*resty.ResponseError); ok && re.Response.RawResponse != nil {
+			return
+		}
+		l.Debug("gitlab request failed",
+			"method", req.Method,
+			"path", logPath(req.URL),
+			"error", err,
+		)
+	}
+}
+
+// logPath strips the query, and any credentials in it, from rawURL
+func logPath(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		if i := strings.IndexByte(rawURL, '?'); i >= 0 {
+			return rawURL[:i]
+		}
+		return rawURL
+	}
+	return u.EscapedPath()
+}
+
+// restyLogger routes resty's own messages to l
+type restyLogger struct {
+	l Logger
+}
+
+func (r restyLogger) Errorf(format string, v ...interface{}) {
+	r.l.Error(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (r restyLogger) Warnf(format string, v ...interface{}) {
+	r.l.Warn(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (r restyLogger) Debugf(format string, v ...interface{}) {
+	r.l.Debug(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
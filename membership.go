@@ -0,0 +1,616 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/maahsome/gron"
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/yaml.v2"
+)
+
+// MembershipSpec is the desired direct membership of groups and projects,
+// keyed by full path:
+//
+//	groups:
+//	  platform/web:
+//	    - user: alice
+//	      access: maintainer
+//	    - group: security
+//	      access: reporter
+//	      expires: 2027-01-31
+//	projects:
+//	  platform/web/site:
+//	    - user: bob
+//	      access: developer
+//	exempt:
+//	  - deploy-bot
+//
+// Each list is authoritative: users and shared groups that are not listed
+// are removed, except the exempt users, the user owning the client's
+// credentials and the bot users of project and group access tokens.
+// Inherited membership is not touched.
+type MembershipSpec struct {
+	Groups   map[string][]MembershipGrant `yaml:"groups"`
+	Projects map[string][]MembershipGrant `yaml:"projects"`
+	// Exempt are usernames never removed from a group or project they are
+	// not listed in
+	Exempt []string `yaml:"exempt,omitempty"`
+}
+
+// MembershipGrant gives a user, or every member of a group, access to a
+// group or project.  Exactly one of User and Group is set.  Access is a
+// name such as "developer" or a number such as 30.  Without Expires an
+// existing expiry is left as it is.
+type MembershipGrant struct {
+	User    string      `yaml:"user,omitempty"`
+	Group   string      `yaml:"group,omitempty"`
+	Access  AccessLevel `yaml:"access"`
+	Expires *ISODate    `yaml:"expires,omitempty"`
+}
+
+// LoadMembershipSpec reads a MembershipSpec from a YAML file
+func LoadMembershipSpec(path string) (*MembershipSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading membership spec %s: %w", path, err)
+	}
+	spec, err := ParseMembershipSpec(data)
+	if err != nil {
+		return nil, fmt.Errorf("membership spec %s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// ParseMembershipSpec decodes and validates a YAML MembershipSpec, unknown
+// fields are an error
+func ParseMembershipSpec(data []byte) (*MembershipSpec, error) {
+
+	spec := &MembershipSpec{}
+	if err := yaml.UnmarshalStrict(data, spec); err != nil {
+		return nil, err
+	}
+	for _, resources := range []map[string][]MembershipGrant{spec.Groups, spec.Projects} {
+		for path, grants := range resources {
+			if err := validateGrants(grants); err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+		}
+	}
+	return spec, nil
+}
+
+func validateGrants(grants []MembershipGrant) error {
+	seen := map[string]bool{}
+	for i, g := range grants {
+		var key string
+		switch {
+		case g.User != "" && g.Group != "":
+			return fmt.Errorf("entry %d: set either user or group, not both", i+1)
+		case g.User != "":
+			key = "user " + g.User
+		case g.Group != "":
+			key = "group " + g.Group
+		default:
+			return fmt.Errorf("entry %d: user or group is required", i+1)
+		}
+		if g.Access == NoAccess {
+			return fmt.Errorf("%s: access is required", key)
+		}
+		if seen[key] {
+			return fmt.Errorf("%s is listed twice", key)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// Membership change actions
+const (
+	MembershipAdd    = "add"
+	MembershipUpdate = "update"
+	MembershipRemove = "remove"
+)
+
+// MembershipPlan - the changes bringing GitLab in line with a
+// MembershipSpec, in the order they are applied
+type MembershipPlan []MembershipChange
+
+// MembershipChange adds, updates or removes one member, or shared group, of
+// a group or project
+type MembershipChange struct {
+	// Action is MembershipAdd, MembershipUpdate or MembershipRemove
+	Action string `json:"action"`
+	// Resource is "group" or "project"
+	Resource   string `json:"resource"`
+	Path       string `json:"path"`
+	ResourceID int    `json:"resource_id"`
+	// Member is a username, or the full path of a shared group when Share
+	// is set
+	Member   string `json:"member"`
+	MemberID int    `json:"member_id"`
+	Share    bool   `json:"share,omitempty"`
+	// From is the current access level, unset for MembershipAdd
+	From AccessLevel `json:"from,omitempty"`
+	// To is the desired access level, unset for MembershipRemove
+	To        AccessLevel `json:"to,omitempty"`
+	ExpiresAt *ISODate    `json:"expires_at,omitempty"`
+	// FromExpiresAt is the current expiry, used to put a share back when
+	// replacing it fails
+	FromExpiresAt *ISODate `json:"from_expires_at,omitempty"`
+}
+
+func (c MembershipChange) String() string {
+	member := c.Member
+	if c.Share {
+		member = "group " + c.Member
+	}
+	expires := ""
+	if c.ExpiresAt != nil {
+		expires = fmt.Sprintf(" until %s", c.ExpiresAt)
+	}
+	switch c.Action {
+	case MembershipAdd:
+		return fmt.Sprintf("add %s to %s %s as %s%s", member, c.Resource, c.Path, c.To, expires)
+	case MembershipUpdate:
+		return fmt.Sprintf("update %s in %s %s from %s to %s%s", member, c.Resource, c.Path, c.From, c.To, expires)
+	default:
+		return fmt.Sprintf("remove %s (%s) from %s %s", member, c.From, c.Resource, c.Path)
+	}
+}
+
+// ErrMembershipDrift is returned by MembershipReconciler.Check when GitLab
+// does not match the spec
+var ErrMembershipDrift = errors.New("membership does not match the spec")
+
+// ErrNoOwnerLeft is returned by MembershipReconciler.Plan when the spec would
+// remove or demote the last direct Owner of a group or project
+var ErrNoOwnerLeft = errors.New("no Owner would be left")
+
+// MembershipReconciler compares group and project membership with a
+// MembershipSpec and applies the difference.  Use Check for a report only
+// run, or a client created WithDryRun to see the requests Apply would send.
+type MembershipReconciler struct {
+	client      GitlabClient
+	users       map[string]int
+	groups      map[string]int
+	currentUser string
+}
+
+// NewMembershipReconciler reconciles membership through client
+func NewMembershipReconciler(client GitlabClient) *MembershipReconciler {
+	return &MembershipReconciler{
+		client: client,
+		users:  map[string]int{},
+		groups: map[string]int{},
+	}
+}
+
+// Plan works out the changes needed to match spec without making them.
+// Groups come before projects, each in path order; within one group or
+// project additions and updates come before removals.  Exempt users, the
+// client's own user and access token bots are never removed, and a plan
+// taking away the last direct Owner of a group or project fails with
+// ErrNoOwnerLeft.
+func (m *MembershipReconciler) Plan(ctx context.Context, spec *MembershipSpec) (MembershipPlan, error) {
+
+	exempt, err := m.exemptions(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	plan := MembershipPlan{}
+	for _, resource := range []struct {
+		name   string
+		grants map[string][]MembershipGrant
+	}{{"group", spec.Groups}, {"project", spec.Projects}} {
+		paths := make([]string, 0, len(resource.grants))
+		for path := range resource.grants {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			changes, err := m.planResource(ctx, resource.name, path, resource.grants[path], exempt)
+			if err != nil {
+				return nil, fmt.Errorf("planning %s %s: %w", resource.name, path, err)
+			}
+			plan = append(plan, changes...)
+		}
+	}
+	return plan, nil
+}
+
+// Check plans spec and returns the drift along with ErrMembershipDrift when
+// there is any
+func (m *MembershipReconciler) Check(ctx context.Context, spec *MembershipSpec) (MembershipPlan, error) {
+	plan, err := m.Plan(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	if len(plan) > 0 {
+		return plan, ErrMembershipDrift
+	}
+	return plan, nil
+}
+
+// Reconcile plans spec and applies the plan, returning the changes made
+func (m *MembershipReconciler) Reconcile(ctx context.Context, spec *MembershipSpec) (MembershipPlan, error) {
+	plan, err := m.Plan(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	return m.Apply(ctx, plan)
+}
+
+// Apply makes the changes in plan in order, stopping at the first failure.
+// It returns the changes that were made.
+func (m *MembershipReconciler) Apply(ctx context.Context, plan MembershipPlan) (MembershipPlan, error) {
+
+	applied := MembershipPlan{}
+	for _, change := range plan {
+		if err := m.apply(ctx, change); err != nil {
+			return applied, fmt.Errorf("%s: %w", change, err)
+		}
+		applied = append(applied, change)
+	}
+	return applied, nil
+}
+
+func (m *MembershipReconciler) apply(ctx context.Context, c MembershipChange) error {
+
+	if c.Share {
+		return m.applyShare(ctx, c)
+	}
+	members := m.client.Members()
+	opts := MemberOptions{AccessLevel: c.To, ExpiresAt: c.ExpiresAt}
+	var err error
+	switch {
+	case c.Resource == "group" && c.Action == MembershipAdd:
+		_, err = members.AddGroupMemberOptsWithContext(ctx, c.ResourceID, c.MemberID, opts)
+	case c.Resource == "group" && c.Action == MembershipUpdate:
+		_, err = members.UpdateGroupMemberWithContext(ctx, c.ResourceID, c.MemberID, opts)
+	case c.Resource == "group":
+		err = members.RemoveGroupMemberWithContext(ctx, c.ResourceID, c.MemberID)
+	case c.Action == MembershipAdd:
+		_, err = members.AddProjectMemberOptsWithContext(ctx, c.ResourceID, c.MemberID, opts)
+	case c.Action == MembershipUpdate:
+		_, err = members.UpdateProjectMemberWithContext(ctx, c.ResourceID, c.MemberID, opts)
+	default:
+		err = members.RemoveProjectMemberWithContext(ctx, c.ResourceID, c.MemberID)
+	}
+	return err
+}
+
+// applyShare shares, or stops sharing, a group or project with a group.
+// GitLab cannot change a share, so an update removes and re-creates it.
+// That is not atomic: when the new share is refused the previous one is
+// put back, and only if that fails too is the group left without access.
+func (m *MembershipReconciler) applyShare(ctx context.Context, c MembershipChange) error {
+
+	if c.Action != MembershipAdd {
+		if err := m.unshare(ctx, c); err != nil {
+			return err
+		}
+	}
+	if c.Action == MembershipRemove {
+		return nil
+	}
+	err := m.share(ctx, c, c.To, c.ExpiresAt)
+	if err == nil || c.Action != MembershipUpdate {
+		return err
+	}
+	if rerr := m.share(ctx, c, c.From, c.FromExpiresAt); rerr != nil {
+		return fmt.Errorf("%w; restoring the previous share failed, %s has no access: %v", err, c.Member, rerr)
+	}
+	return fmt.Errorf("%w; the previous share was restored", err)
+}
+
+func (m *MembershipReconciler) share(ctx context.Context, c MembershipChange, access AccessLevel, expiresAt *ISODate) error {
+	var err error
+	if c.Resource == "group" {
+		_, err = m.client.Groups().ShareGroupWithContext(ctx, c.ResourceID, c.MemberID, access, expiresAt)
+	} else {
+		_, err = m.client.Projects().ShareProjectWithContext(ctx, c.ResourceID, c.MemberID, access, expiresAt)
+	}
+	return err
+}
+
+func (m *MembershipReconciler) unshare(ctx context.Context, c MembershipChange) error {
+	if c.Resource == "group" {
+		return m.client.Groups().UnshareGroupWithContext(ctx, c.ResourceID, c.MemberID)
+	}
+	return m.client.Projects().UnshareProjectWithContext(ctx, c.ResourceID, c.MemberID)
+}
+
+// exemptions are the usernames planResource never removes: spec.Exempt and
+// the user owning the client's credentials
+func (m *MembershipReconciler) exemptions(ctx context.Context, spec *MembershipSpec) (map[string]bool, error) {
+
+	if m.currentUser == "" {
+		user, err := m.client.Users().GetCurrentUserWithContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("looking up the current user: %w", err)
+		}
+		m.currentUser = user.Username
+	}
+	exempt := map[string]bool{m.currentUser: true}
+	for _, username := range spec.Exempt {
+		exempt[username] = true
+	}
+	return exempt, nil
+}
+
+// planResource compares the direct members and shares of one group or
+// project with grants
+func (m *MembershipReconciler) planResource(ctx context.Context, resource, path string, grants []MembershipGrant, exempt map[string]bool) ([]MembershipChange, error) {
+
+	var (
+		id      int
+		members Members
+		shares  []SharedGroup
+		err     error
+	)
+	if resource == "group" {
+		if id, err = m.groupID(ctx, path); err != nil {
+			return nil, err
+		}
+		group, err := m.client.Groups().GetGroupWithContext(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		shares = group.SharedWithGroups
+		members, err = m.client.Members().ListGroupMembersWithContext(ctx, id, nil)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if id, err = m.client.Projects().GetProjectIDWithContext(ctx, path); err != nil {
+			return nil, err
+		}
+		project, err := m.client.Projects().GetProjectWithContext(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		shares = project.SharedWithGroups
+		members, err = m.client.Members().ListProjectMembersWithContext(ctx, id, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	currentMembers := map[int]Member{}
+	for _, member := range members {
+		currentMembers[member.ID] = member
+	}
+	currentShares := map[int]SharedGroup{}
+	for _, share := range shares {
+		currentShares[share.GroupID] = share
+	}
+
+	var changes, removals []MembershipChange
+	change := func(action, member string, memberID int, share bool, from AccessLevel, fromExpires *ISODate, grant MembershipGrant) {
+		expires := grant.Expires
+		if share && expires == nil {
+			// re-creating the share must not drop an expiry the spec
+			// leaves alone
+			expires = fromExpires
+		}
+		changes = append(changes, MembershipChange{
+			Action: action, Resource: resource, Path: path, ResourceID: id,
+			Member: member, MemberID: memberID, Share: share,
+			From: from, To: grant.Access, ExpiresAt: expires, FromExpiresAt: fromExpires,
+		})
+	}
+	for _, grant := range grants {
+		if grant.User != "" {
+			userID, err := m.userID(ctx, grant.User)
+			if err != nil {
+				return nil, err
+			}
+			current, ok := currentMembers[userID]
+			delete(currentMembers, userID)
+			switch {
+			case !ok:
+				change(MembershipAdd, grant.User, userID, false, NoAccess, nil, grant)
+			case current.AccessLevel != grant.Access || expiryDiffers(current.ExpiresAt, grant.Expires):
+				change(MembershipUpdate, grant.User, userID, false, current.AccessLevel, current.ExpiresAt, grant)
+			}
+			continue
+		}
+		groupID, err := m.groupID(ctx, grant.Group)
+		if err != nil {
+			return nil, err
+		}
+		current, ok := currentShares[groupID]
+		delete(currentShares, groupID)
+		switch {
+		case !ok:
+			change(MembershipAdd, grant.Group, groupID, true, NoAccess, nil, grant)
+		case current.GroupAccessLevel != grant.Access || expiryDiffers(current.ExpiresAt, grant.Expires):
+			change(MembershipUpdate, grant.Group, groupID, true, current.GroupAccessLevel, current.ExpiresAt, grant)
+		}
+	}
+
+	for _, member := range currentMembers {
+		// removing a bot user revokes its project or group access token
+		if exempt[member.Username] || member.Bot {
+			continue
+		}
+		removals = append(removals, MembershipChange{
+			Action: MembershipRemove, Resource: resource, Path: path, ResourceID: id,
+			Member: member.Username, MemberID: member.ID, From: member.AccessLevel, FromExpiresAt: member.ExpiresAt,
+		})
+	}
+	for _, share := range currentShares {
+		removals = append(removals, MembershipChange{
+			Action: MembershipRemove, Resource: resource, Path: path, ResourceID: id,
+			Member: share.GroupFullPath, MemberID: share.GroupID, Share: true, From: share.GroupAccessLevel, FromExpiresAt: share.ExpiresAt,
+		})
+	}
+	sort.Slice(removals, func(i, j int) bool {
+		if removals[i].Share != removals[j].Share {
+			return !removals[i].Share
+		}
+		return removals[i].Member < removals[j].Member
+	})
+	changes = append(changes, removals...)
+
+	if owners(members) > 0 && owners(members)+ownerDelta(changes) <= 0 {
+		return nil, fmt.Errorf("%w: the spec removes or demotes every direct Owner", ErrNoOwnerLeft)
+	}
+	return changes, nil
+}
+
+// owners counts the Owners among members
+func owners(members Members) int {
+	n := 0
+	for _, member := range members {
+		if member.AccessLevel == OwnerAccess {
+			n++
+		}
+	}
+	return n
+}
+
+// ownerDelta is how many direct Owners changes add, less those they remove
+// or demote.  Shares never grant Owner.
+func ownerDelta(changes []MembershipChange) int {
+	delta := 0
+	for _, c := range changes {
+		if c.Share {
+			continue
+		}
+		if c.Action != MembershipRemove && c.To == OwnerAccess {
+			delta++
+		}
+		if c.Action != MembershipAdd && c.From == OwnerAccess {
+			delta--
+		}
+	}
+	return delta
+}
+
+// expiryDiffers reports whether desired is set and differs from current
+func expiryDiffers(current, desired *ISODate) bool {
+	if desired == nil {
+		return false
+	}
+	return current == nil || current.String() != desired.String()
+}
+
+func (m *MembershipReconciler) userID(ctx context.Context, username string) (int, error) {
+	if id, ok := m.users[username]; ok {
+		return id, nil
+	}
+	user, err := m.client.Users().GetUserByUsernameWithContext(ctx, username)
+	if err != nil {
+		return 0, fmt.Errorf("looking up user %s: %w", username, err)
+	}
+	m.users[username] = user.ID
+	return user.ID, nil
+}
+
+func (m *MembershipReconciler) groupID(ctx context.Context, path string) (int, error) {
+	if id, ok := m.groups[path]; ok {
+		return id, nil
+	}
+	id, err := m.client.Groups().GetGroupIDWithContext(ctx, path)
+	if err != nil {
+		return 0, fmt.Errorf("looking up group %s: %w", path, err)
+	}
+	m.groups[path] = id
+	return id, nil
+}
+
+// ToJSON - Write the output as JSON
+func (mp *MembershipPlan) ToJSON() string {
+	mpJSON, err := json.MarshalIndent(mp, "", "  ")
+	if err != nil {
+		packageLogger().Error("Error extracting JSON", "error", err)
+		return ""
+	}
+	return string(mpJSON[:])
+}
+
+func (mp *MembershipPlan) ToGRON() string {
+	mpJSON, err := json.MarshalIndent(mp, "", "  ")
+	if err != nil {
+		packageLogger().Error("Error extracting JSON for GRON", "error", err)
+	}
+	subReader := strings.NewReader(string(mpJSON[:]))
+	subValues := &bytes.Buffer{}
+	ges := gron.NewGron(subReader, subValues)
+	ges.SetMonochrome(false)
+	if serr := ges.ToGron(); serr != nil {
+		packageLogger().Error("Problem generating GRON syntax", "error", serr)
+		return ""
+	}
+	return string(subValues.Bytes())
+}
+
+func (mp *MembershipPlan) ToYAML() string {
+	mpYAML, err := yaml.Marshal(mp)
+	if err != nil {
+		packageLogger().Error("Error extracting YAML", "error", err)
+		return ""
+	}
+	return string(mpYAML[:])
+}
+
+func (mp *MembershipPlan) ToTEXT(noHeaders bool) string {
+	buf, row := new(bytes.Buffer), make([]string, 0)
+
+	// ************************** TableWriter ******************************
+	table := tablewriter.NewWriter(buf)
+	if !noHeaders {
+		table.SetHeader([]string{"ACTION", "RESOURCE", "PATH", "MEMBER", "FROM", "TO", "EXPIRES"})
+		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	}
+
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(true)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetBorder(false)
+	table.SetTablePadding("\t") // pad with tabs
+	table.SetNoWhiteSpace(true)
+
+	for _, v := range *mp {
+		member, from, to, expires := v.Member, "", "", ""
+		if v.Share {
+			member = "group " + v.Member
+		}
+		if v.Action != MembershipAdd {
+			from = v.From.String()
+		}
+		if v.Action != MembershipRemove {
+			to = v.To.String()
+		}
+		if v.ExpiresAt != nil {
+			expires = v.ExpiresAt.String()
+		}
+		row = []string{
+			v.Action,
+			v.Resource,
+			v.Path,
+			member,
+			from,
+			to,
+			expires,
+		}
+		table.Append(row)
+	}
+
+	table.Render()
+
+	return buf.String()
+
+}
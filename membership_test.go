@@ -0,0 +1,193 @@
+package gitlab_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	gitlab "github.com/maahsome/gitlab-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMembershipReconcilerRestoresShare(t *testing.T) {
+
+	ctx := context.Background()
+	client := &gitlab.GitlabMock{}
+	client.On("UnshareGroupWithContext", ctx, 1, 2).Return(nil).Once()
+	client.On("ShareGroupWithContext", ctx, 1, 2, gitlab.MaintainerAccess, (*gitlab.ISODate)(nil)).
+		Return(gitlab.Group{}, errors.New("403 Forbidden")).Once()
+	client.On("ShareGroupWithContext", ctx, 1, 2, gitlab.ReporterAccess, (*gitlab.ISODate)(nil)).
+		Return(gitlab.Group{ID: 1}, nil).Once()
+
+	plan := gitlab.MembershipPlan{{
+		Action:     gitlab.MembershipUpdate,
+		Resource:   "group",
+		Path:       "platform",
+		ResourceID: 1,
+		Member:     "security",
+		MemberID:   2,
+		Share:      true,
+		From:       gitlab.ReporterAccess,
+		To:         gitlab.MaintainerAccess,
+	}}
+	applied, err := gitlab.NewMembershipReconciler(client).Apply(ctx, plan)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "previous share was restored")
+	assert.Empty(t, applied)
+
+	client.AssertExpectations(t)
+}
+
+func TestMembershipReconcilerSkipsTokenOwner(t *testing.T) {
+
+	ctx := context.Background()
+	client := &gitlab.GitlabMock{}
+	client.On("GetCurrentUserWithContext", ctx).Return(gitlab.User{ID: 1, Username: "operator"}, nil)
+	client.On("GetGroupIDWithContext", ctx, "platform").Return(10, nil)
+	client.On("GetGroupWithContext", ctx, 10).Return(gitlab.Group{ID: 10, FullPath: "platform"}, nil)
+	client.On("ListGroupMembersWithContext", ctx, 10, (*gitlab.ListMembersOptions)(nil)).Return(gitlab.Members{
+		{ID: 1, Username: "operator", AccessLevel: gitlab.OwnerAccess},
+		{ID: 2, Username: "alice", AccessLevel: gitlab.DeveloperAccess},
+	}, nil)
+
+	spec := &gitlab.MembershipSpec{Groups: map[string][]gitlab.MembershipGrant{"platform": {}}}
+	plan, err := gitlab.NewMembershipReconciler(client).Plan(ctx, spec)
+	require.NoError(t, err)
+	require.Len(t, plan, 1)
+	assert.Equal(t, gitlab.MembershipRemove, plan[0].Action)
+	assert.Equal(t, "alice", plan[0].Member)
+
+	client.AssertExpectations(t)
+}
+
+func TestMembershipReconcilerKeepsBots(t *testing.T) {
+
+	ctx := context.Background()
+	fake, client := newFakeClient(t)
+	operator := fake.AddUser("operator", "Operator")
+	fake.SetTokenOwner(operator)
+	alice := fake.AddUser("alice", "Alice")
+	bot := fake.AddUserDetails(gitlab.User{Username: "group_10_bot_5f1c", Name: "deploy", Bot: true})
+	web := fake.AddGroup(gitlab.Group{Path: "web"})
+	fake.AddMember("groups", web.ID, operator, int(gitlab.OwnerAccess))
+	fake.AddMember("groups", web.ID, alice, int(gitlab.DeveloperAccess))
+	fake.AddMember("groups", web.ID, bot.ID, int(gitlab.MaintainerAccess))
+
+	spec, err := gitlab.ParseMembershipSpec([]byte("groups: {web: []}"))
+	require.NoError(t, err)
+	applied, err := gitlab.NewMembershipReconciler(client).Reconcile(ctx, spec)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"remove web alice"}, planSummary(applied))
+
+	level, ok := fake.MemberAccessLevel("groups", web.ID, bot.ID)
+	assert.True(t, ok)
+	assert.Equal(t, int(gitlab.MaintainerAccess), level)
+	_, ok = fake.MemberAccessLevel("groups", web.ID, alice)
+	assert.False(t, ok)
+}
+
+// planSummary is "action path member" for each change in plan
+func planSummary(plan gitlab.MembershipPlan) []string {
+	summary := make([]string, 0, len(plan))
+	for _, c := range plan {
+		summary = append(summary, fmt.Sprintf("%s %s %s", c.Action, c.Path, c.Member))
+	}
+	return summary
+}
+
+func TestMembershipPlanOrder(t *testing.T) {
+
+	ctx := context.Background()
+	fake, client := newFakeClient(t)
+	operator := fake.AddUser("operator", "Operator")
+	fake.SetTokenOwner(operator)
+	alice := fake.AddUser("alice", "Alice")
+	bob := fake.AddUser("bob", "Bob")
+	carol := fake.AddUser("carol", "Carol")
+	fake.AddUser("dave", "Dave")
+	web := fake.AddGroup(gitlab.Group{Path: "web"})
+	api := fake.AddGroup(gitlab.Group{Path: "api"})
+	security := fake.AddGroup(gitlab.Group{Path: "security"})
+	site := fake.AddProject(web.ID, gitlab.Project{Path: "site"})
+
+	fake.AddMember("groups", web.ID, operator, int(gitlab.OwnerAccess))
+	fake.AddMember("groups", web.ID, alice, int(gitlab.DeveloperAccess))
+	fake.AddMember("groups", web.ID, bob, int(gitlab.ReporterAccess))
+	fake.AddMember("groups", web.ID, carol, int(gitlab.GuestAccess))
+	fake.AddMember("groups", api.ID, operator, int(gitlab.OwnerAccess))
+	fake.AddMember("groups", api.ID, bob, int(gitlab.DeveloperAccess))
+	fake.AddMember("groups", api.ID, carol, int(gitlab.GuestAccess))
+	fake.AddMember("projects", site.ID, carol, int(gitlab.DeveloperAccess))
+	_, err := client.Groups().ShareGroup(api.ID, security.ID, gitlab.ReporterAccess, nil)
+	require.NoError(t, err)
+
+	spec, err := gitlab.ParseMembershipSpec([]byte(`
+groups:
+  web:
+    - user: dave
+      access: developer
+    - user: carol
+      access: maintainer
+    - user: alice
+      access: developer
+  api:
+    - user: bob
+      access: developer
+    - user: alice
+      access: guest
+projects:
+  web/site:
+    - user: bob
+      access: reporter
+`))
+	require.NoError(t, err)
+
+	plan, err := gitlab.NewMembershipReconciler(client).Plan(ctx, spec)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"add api alice",
+		"remove api carol",
+		"remove api security",
+		"add web dave",
+		"update web carol",
+		"remove web bob",
+		"add web/site bob",
+		"remove web/site carol",
+	}, planSummary(plan))
+}
+
+func TestMembershipPlanNoOwnerLeft(t *testing.T) {
+
+	ctx := context.Background()
+	fake, client := newFakeClient(t)
+	operator := fake.AddUser("operator", "Operator")
+	fake.SetTokenOwner(operator)
+	alice := fake.AddUser("alice", "Alice")
+	fake.AddUser("bob", "Bob")
+	web := fake.AddGroup(gitlab.Group{Path: "web"})
+	fake.AddMember("groups", web.ID, operator, int(gitlab.DeveloperAccess))
+	fake.AddMember("groups", web.ID, alice, int(gitlab.OwnerAccess))
+
+	for name, tc := range map[string]struct {
+		spec    string
+		wantErr bool
+	}{
+		"demote":   {"groups: {web: [{user: alice, access: maintainer}]}", true},
+		"remove":   {"groups: {web: [{user: bob, access: developer}]}", true},
+		"handover": {"groups: {web: [{user: alice, access: maintainer}, {user: bob, access: owner}]}", false},
+		"keep":     {"groups: {web: [{user: alice, access: owner}]}", false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			spec, err := gitlab.ParseMembershipSpec([]byte(tc.spec))
+			require.NoError(t, err)
+			_, err = gitlab.NewMembershipReconciler(client).Plan(ctx, spec)
+			if tc.wantErr {
+				assert.True(t, errors.Is(err, gitlab.ErrNoOwnerLeft), "got %v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
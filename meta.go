@@ -0,0 +1,115 @@
+package gitlab
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// WithResponseObserver registers fn to be called with the ResponseMeta of
+// every response the client receives, including retried attempts
+func WithResponseObserver(fn func(ResponseMeta)) ClientOption {
+	return func(c *clientConfig) error {
+		c.responseObservers = append(c.responseObservers, fn)
+		return nil
+	}
+}
+
+// MetaRecorder collects the ResponseMeta of the responses received on behalf
+// of a single context, see RecordResponseMeta
+type MetaRecorder struct {
+	mu    sync.Mutex
+	metas []ResponseMeta
+}
+
+type metaRecorderKey struct{}
+
+// RecordResponseMeta returns a context that records the ResponseMeta of every
+// response received by calls made with it
+//
+//	ctx, rec := gitlab.RecordResponseMeta(ctx)
+//	project, err := client.GetProjectWithContext(ctx, 42)
+//	meta, _ := rec.Last()
+func RecordResponseMeta(ctx context.Context) (context.Context, *MetaRecorder) {
+	rec := &MetaRecorder{}
+	return context.WithValue(ctx, metaRecorderKey{}, rec), rec
+}
+
+// All returns the recorded ResponseMeta in the order the responses arrived
+func (m *MetaRecorder) All() []ResponseMeta {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]ResponseMeta(nil), m.metas...)
+}
+
+// Last returns the most recent ResponseMeta, false when nothing was recorded
+func (m *MetaRecorder) Last() (ResponseMeta, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.metas) == 0 {
+		return ResponseMeta{}, false
+	}
+	return m.metas[len(m.metas)-1], true
+}
+
+func (m *MetaRecorder) add(meta ResponseMeta) {
+	m.mu.Lock()
+	m.metas = append(m.metas, meta)
+	m.mu.Unlock()
+}
+
+// newResponseMeta fills a ResponseMeta from resp and GitLab's headers
+func newResponseMeta(resp *resty.Response) ResponseMeta {
+
+	header := resp.Header()
+	headerInt := func(key string) int {
+		v, _ := strconv.Atoi(header.Get(key))
+		return v
+	}
+	runtime, _ := strconv.ParseFloat(header.Get("X-Runtime"), 64)
+
+	meta := ResponseMeta{
+		StatusCode: resp.StatusCode(),
+		RequestId:  header.Get("X-Request-Id"),
+		Page:       headerInt("X-Page"),
+		PerPage:    headerInt("X-Per-Page"),
+		PrevPage:   headerInt("X-Prev-Page"),
+		NextPage:   headerInt("X-Next-Page"),
+		TotalPages: headerInt("X-Total-Pages"),
+		Total:      headerInt("X-Total"),
+		Runtime:    runtime,
+		Duration:   resp.Time(),
+		Cached:     header.Get("X-From-Cache") != "",
+	}
+	if resp.Request != nil {
+		meta.Method = resp.Request.Method
+		meta.Url = resp.Request.URL
+	}
+	return meta
+}
+
+// metaMiddleware hands the ResponseMeta of each response to the client's
+// observers and to any MetaRecorder on the request context
+func metaMiddleware(observers []func(ResponseMeta)) resty.ResponseMiddleware {
+	return func(c *resty.Client, resp *resty.Response) error {
+
+		var rec *MetaRecorder
+		if resp.Request != nil {
+			rec, _ = resp.Request.Context().Value(metaRecorderKey{}).(*MetaRecorder)
+		}
+		if rec == nil && len(observers) == 0 {
+			return nil
+		}
+
+		meta := newResponseMeta(resp)
+		for _, fn := range observers {
+			fn(meta)
+		}
+		if rec != nil {
+			rec.add(meta)
+		}
+		return nil
+	}
+}
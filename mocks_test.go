@@ -0,0 +1,81 @@
+package gitlab_test
+
+import (
+	"context"
+	"testing"
+
+	gitlab "github.com/maahsome/gitlab-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// subgroupPaths is the kind of caller code GroupsMock stands in for: it
+// only needs the groups part of the client
+func subgroupPaths(ctx context.Context, groups gitlab.GroupsService, groupID int) ([]string, error) {
+	subgroups, err := groups.ListSubGroupsWithContext(ctx, groupID, nil)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(subgroups))
+	for _, g := range subgroups {
+		paths = append(paths, g.FullPath)
+	}
+	return paths, nil
+}
+
+func TestGroupsMock(t *testing.T) {
+
+	ctx := context.Background()
+	groups := &gitlab.GroupsMock{}
+	groups.On("ListSubGroupsWithContext", ctx, 7, (*gitlab.ListGroupsOptions)(nil)).
+		Return(gitlab.GroupList{{ID: 8, FullPath: "platform/web"}, {ID: 9, FullPath: "platform/api"}}, nil)
+	groups.On("ListSubGroupsWithContext", ctx, 404, (*gitlab.ListGroupsOptions)(nil)).
+		Return(nil, &gitlab.RequestError{StatusCode: 404, Message: "404 Group Not Found"})
+
+	paths, err := subgroupPaths(ctx, groups, 7)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"platform/web", "platform/api"}, paths)
+
+	_, err = subgroupPaths(ctx, groups, 404)
+	assert.True(t, gitlab.IsNotFound(err))
+
+	groups.AssertExpectations(t)
+}
+
+func TestGitlabMockReturnFunc(t *testing.T) {
+
+	client := &gitlab.GitlabMock{}
+	client.On("GetProjectID", mock.AnythingOfType("string")).
+		Return(func(path string) int { return len(path) }, nil)
+
+	id, err := client.GetProjectID("group/project")
+	require.NoError(t, err)
+	assert.Equal(t, len("group/project"), id)
+
+	client.AssertExpectations(t)
+}
+
+func TestLegacyMockServices(t *testing.T) {
+
+	client := gitlab.NewGitlabMock("https://gitlab.example.com", "/api/v4", "token")
+
+	_, err := client.Projects().GetProject(0)
+	assert.True(t, gitlab.IsNotFound(err))
+	deletion, err := client.Groups().DeleteGroup(7, nil)
+	require.NoError(t, err)
+	assert.Equal(t, gitlab.GroupDeletion{}, deletion)
+
+	// calls without a canned answer fail as unexpected, unless programmed
+	opts := gitlab.CreateGroupOptions{Path: "platform"}
+	assert.Panics(t, func() { _, _ = client.Groups().CreateGroup(opts) })
+	mocks := gitlab.MockServices(client)
+	require.NotNil(t, mocks)
+	mocks.GroupsMock.On("CreateGroup", opts).Return(gitlab.Group{ID: 8, Path: "platform"}, nil).Once()
+	group, err := client.Groups().CreateGroup(opts)
+	require.NoError(t, err)
+	assert.Equal(t, 8, group.ID)
+	mocks.GroupsMock.AssertExpectations(t)
+
+	assert.Nil(t, gitlab.MockServices(&gitlab.GitlabMock{}))
+}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/maahsome/gron"
 	"github.com/olekukonko/tablewriter"
-	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 )
 
@@ -27,7 +26,7 @@ type Variable struct {
 func (v *Variables) ToJSON() string {
 	vJSON, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
-		logrus.WithError(err).Error("Error extracting JSON")
+		packageLogger().Error("Error extracting JSON", "error", err)
 		return ""
 	}
 	return string(vJSON[:])
@@ -36,14 +35,14 @@ func (v *Variables) ToJSON() string {
 func (v *Variables) ToGRON() string {
 	vJSON, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
-		logrus.WithError(err).Error("Error extracting JSON for GRON")
+		packageLogger().Error("Error extracting JSON for GRON", "error", err)
 	}
 	subReader := strings.NewReader(string(vJSON[:]))
 	subValues := &bytes.Buffer{}
 	ges := gron.NewGron(subReader, subValues)
 	ges.SetMonochrome(false)
 	if serr := ges.ToGron(); serr != nil {
-		logrus.WithError(serr).Error("Problem generating GRON syntax")
+		packageLogger().Error("Problem generating GRON syntax", "error", serr)
 		return ""
 	}
 	return string(subValues.Bytes())
@@ -52,7 +51,7 @@ func (v *Variables) ToGRON() string {
 func (v *Variables) ToYAML() string {
 	vYAML, err := yaml.Marshal(v)
 	if err != nil {
-		logrus.WithError(err).Error("Error extracting YAML")
+		packageLogger().Error("Error extracting YAML", "error", err)
 		return ""
 	}
 	return string(vYAML[:])
@@ -93,3 +92,8 @@ func (v *Variables) ToTEXT(noHeaders bool) string {
 	return buf.String()
 
 }
+
+// updateVariableRequest is the body of UpdateVariableFrom
+type updateVariableRequest struct {
+	Value string `json:"value"`
+}
@@ -11,45 +11,144 @@ import (
 	"github.com/maahsome/gron"
 	"github.com/muesli/termenv"
 	"github.com/olekukonko/tablewriter"
-	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 )
 
 type GroupList []Group
 
 type Group struct {
-	ID                             int         `json:"id"`
-	WebURL                         string      `json:"web_url"`
-	Name                           string      `json:"name"`
-	Path                           string      `json:"path"`
-	Description                    string      `json:"description"`
-	Visibility                     string      `json:"visibility"`
-	ShareWithGroupLock             bool        `json:"share_with_group_lock"`
-	RequireTwoFactorAuthentication bool        `json:"require_two_factor_authentication"`
-	TwoFactorGracePeriod           int         `json:"two_factor_grace_period"`
-	ProjectCreationLevel           string      `json:"project_creation_level"`
-	AutoDevopsEnabled              interface{} `json:"auto_devops_enabled"`
-	SubgroupCreationLevel          string      `json:"subgroup_creation_level"`
-	EmailsDisabled                 interface{} `json:"emails_disabled"`
-	MentionsDisabled               interface{} `json:"mentions_disabled"`
-	LfsEnabled                     bool        `json:"lfs_enabled"`
-	DefaultBranchProtection        int         `json:"default_branch_protection"`
-	AvatarURL                      interface{} `json:"avatar_url"`
-	RequestAccessEnabled           bool        `json:"request_access_enabled"`
-	FullName                       string      `json:"full_name"`
-	FullPath                       string      `json:"full_path"`
-	CreatedAt                      time.Time   `json:"created_at"`
-	ParentID                       int         `json:"parent_id"`
-	LdapCn                         interface{} `json:"ldap_cn"`
-	LdapAccess                     interface{} `json:"ldap_access"`
-	MarkedForDeletionOn            interface{} `json:"marked_for_deletion_on"`
+	ID                             int           `json:"id"`
+	WebURL                         string        `json:"web_url"`
+	Name                           string        `json:"name"`
+	Path                           string        `json:"path"`
+	Description                    string        `json:"description"`
+	Visibility                     string        `json:"visibility"`
+	ShareWithGroupLock             bool          `json:"share_with_group_lock"`
+	RequireTwoFactorAuthentication bool          `json:"require_two_factor_authentication"`
+	TwoFactorGracePeriod           int           `json:"two_factor_grace_period"`
+	ProjectCreationLevel           string        `json:"project_creation_level"`
+	AutoDevopsEnabled              interface{}   `json:"auto_devops_enabled"`
+	SubgroupCreationLevel          string        `json:"subgroup_creation_level"`
+	EmailsDisabled                 interface{}   `json:"emails_disabled"`
+	MentionsDisabled               interface{}   `json:"mentions_disabled"`
+	LfsEnabled                     bool          `json:"lfs_enabled"`
+	DefaultBranchProtection        int           `json:"default_branch_protection"`
+	AvatarURL                      interface{}   `json:"avatar_url"`
+	RequestAccessEnabled           bool          `json:"request_access_enabled"`
+	FullName                       string        `json:"full_name"`
+	FullPath                       string        `json:"full_path"`
+	CreatedAt                      time.Time     `json:"created_at"`
+	ParentID                       int           `json:"parent_id"`
+	LdapCn                         interface{}   `json:"ldap_cn"`
+	LdapAccess                     interface{}   `json:"ldap_access"`
+	MarkedForDeletionOn            interface{}   `json:"marked_for_deletion_on"`
+	SharedWithGroups               []SharedGroup `json:"shared_with_groups,omitempty"`
+}
+
+// Who may create projects in a group, Group.ProjectCreationLevel
+const (
+	ProjectCreationNoOne      = "noone"
+	ProjectCreationMaintainer = "maintainer"
+	ProjectCreationDeveloper  = "developer"
+)
+
+// Who may create subgroups in a group, Group.SubgroupCreationLevel
+const (
+	SubgroupCreationOwner      = "owner"
+	SubgroupCreationMaintainer = "maintainer"
+)
+
+// CreateGroupOptions - the settings of a new group, Name and Path are
+// required and ParentID makes it a subgroup
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/groups.html#new-group
+type CreateGroupOptions struct {
+	Name                           string `json:"name"`
+	Path                           string `json:"path"`
+	ParentID                       int    `json:"parent_id,omitempty"`
+	Description                    string `json:"description,omitempty"`
+	Visibility                     string `json:"visibility,omitempty"`
+	ProjectCreationLevel           string `json:"project_creation_level,omitempty"`
+	SubgroupCreationLevel          string `json:"subgroup_creation_level,omitempty"`
+	RequireTwoFactorAuthentication *bool  `json:"require_two_factor_authentication,omitempty"`
+	TwoFactorGracePeriod           *int   `json:"two_factor_grace_period,omitempty"`
+	DefaultBranchProtection        *int   `json:"default_branch_protection,omitempty"`
+	LfsEnabled                     *bool  `json:"lfs_enabled,omitempty"`
+	RequestAccessEnabled           *bool  `json:"request_access_enabled,omitempty"`
+	ShareWithGroupLock             *bool  `json:"share_with_group_lock,omitempty"`
+	AutoDevopsEnabled              *bool  `json:"auto_devops_enabled,omitempty"`
+	EmailsDisabled                 *bool  `json:"emails_disabled,omitempty"`
+	MentionsDisabled               *bool  `json:"mentions_disabled,omitempty"`
+}
+
+// UpdateGroupOptions - the group settings to change, nil fields are left
+// as they are
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/groups.html#update-group
+type UpdateGroupOptions struct {
+	Name                           *string `json:"name,omitempty"`
+	Path                           *string `json:"path,omitempty"`
+	Description                    *string `json:"description,omitempty"`
+	Visibility                     *string `json:"visibility,omitempty"`
+	ProjectCreationLevel           *string `json:"project_creation_level,omitempty"`
+	SubgroupCreationLevel          *string `json:"subgroup_creation_level,omitempty"`
+	RequireTwoFactorAuthentication *bool   `json:"require_two_factor_authentication,omitempty"`
+	TwoFactorGracePeriod           *int    `json:"two_factor_grace_period,omitempty"`
+	DefaultBranchProtection        *int    `json:"default_branch_protection,omitempty"`
+	LfsEnabled                     *bool   `json:"lfs_enabled,omitempty"`
+	RequestAccessEnabled           *bool   `json:"request_access_enabled,omitempty"`
+	ShareWithGroupLock             *bool   `json:"share_with_group_lock,omitempty"`
+	AutoDevopsEnabled              *bool   `json:"auto_devops_enabled,omitempty"`
+	EmailsDisabled                 *bool   `json:"emails_disabled,omitempty"`
+	MentionsDisabled               *bool   `json:"mentions_disabled,omitempty"`
+}
+
+// DeleteGroupOptions - permanently remove a subgroup that is already marked
+// for deletion instead of waiting for the deletion delay, FullPath must
+// repeat the group's full path as a safeguard
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/groups.html#remove-group
+type DeleteGroupOptions struct {
+	PermanentlyRemove bool   `url:"permanently_remove,omitempty"`
+	FullPath          string `url:"full_path,omitempty"`
+}
+
+// GroupDeletion - the outcome of DeleteGroup, read back from GitLab after
+// the DELETE
+type GroupDeletion struct {
+	// Scheduled is set when GitLab delays the deletion (delayed group
+	// deletion): the group is marked on MarkedForDeletionOn and can be
+	// restored with RestoreGroup until it is removed, after the instance's
+	// deletion_adjourned_period.  Otherwise GitLab removes the group in the
+	// background.
+	Scheduled           bool
+	MarkedForDeletionOn *ISODate
+	// DryRun is set when the client was created WithDryRun, nothing was
+	// sent and whether GitLab would delay the deletion is unknown
+	DryRun bool
+}
+
+// transferGroupRequest is the body of TransferGroup, no group_id makes the
+// group top level
+type transferGroupRequest struct {
+	GroupID int `json:"group_id,omitempty"`
+}
+
+// shareRequest is the body of the group and project share calls
+type shareRequest struct {
+	GroupID     int         `json:"group_id"`
+	GroupAccess AccessLevel `json:"group_access"`
+	ExpiresAt   *ISODate    `json:"expires_at,omitempty"`
 }
 
 // ToJSON - Write the output as JSON
 func (gr *GroupList) ToJSON() string {
 	grJSON, err := json.MarshalIndent(gr, "", "  ")
 	if err != nil {
-		logrus.WithError(err).Error("Error extracting JSON")
+		packageLogger().Error("Error extracting JSON", "error", err)
 		return ""
 	}
 	return string(grJSON[:])
@@ -58,14 +157,14 @@ func (gr *GroupList) ToJSON() string {
 func (gr *GroupList) ToGRON() string {
 	grJSON, err := json.MarshalIndent(gr, "", "  ")
 	if err != nil {
-		logrus.WithError(err).Error("Error extracting JSON for GRON")
+		packageLogger().Error("Error extracting JSON for GRON", "error", err)
 	}
 	subReader := strings.NewReader(string(grJSON[:]))
 	subValues := &bytes.Buffer{}
 	ges := gron.NewGron(subReader, subValues)
 	ges.SetMonochrome(false)
 	if serr := ges.ToGron(); serr != nil {
-		logrus.WithError(serr).Error("Problem generating GRON syntax")
+		packageLogger().Error("Problem generating GRON syntax", "error", serr)
 		return ""
 	}
 	return string(subValues.Bytes())
@@ -74,7 +173,7 @@ func (gr *GroupList) ToGRON() string {
 func (gr *GroupList) ToYAML() string {
 	grYAML, err := yaml.Marshal(gr)
 	if err != nil {
-		logrus.WithError(err).Error("Error extracting YAML")
+		packageLogger().Error("Error extracting YAML", "error", err)
 		return ""
 	}
 	return string(grYAML[:])
@@ -128,7 +227,7 @@ func (gr *GroupList) ToTEXT(noHeaders bool, user string) string {
 func (gr *Group) ToJSON() string {
 	grJSON, err := json.MarshalIndent(gr, "", "  ")
 	if err != nil {
-		logrus.WithError(err).Error("Error extracting JSON")
+		packageLogger().Error("Error extracting JSON", "error", err)
 		return ""
 	}
 	return string(grJSON[:])
@@ -137,14 +236,14 @@ func (gr *Group) ToJSON() string {
 func (gr *Group) ToGRON() string {
 	grJSON, err := json.MarshalIndent(gr, "", "  ")
 	if err != nil {
-		logrus.WithError(err).Error("Error extracting JSON for GRON")
+		packageLogger().Error("Error extracting JSON for GRON", "error", err)
 	}
 	subReader := strings.NewReader(string(grJSON[:]))
 	subValues := &bytes.Buffer{}
 	ges := gron.NewGron(subReader, subValues)
 	ges.SetMonochrome(false)
 	if serr := ges.ToGron(); serr != nil {
-		logrus.WithError(serr).Error("Problem generating GRON syntax")
+		packageLogger().Error("Problem generating GRON syntax", "error", serr)
 		return ""
 	}
 	return string(subValues.Bytes())
@@ -153,7 +252,7 @@ func (gr *Group) ToGRON() string {
 func (gr *Group) ToYAML() string {
 	grYAML, err := yaml.Marshal(gr)
 	if err != nil {
-		logrus.WithError(err).Error("Error extracting YAML")
+		packageLogger().Error("Error extracting YAML", "error", err)
 		return ""
 	}
 	return string(grYAML[:])
@@ -0,0 +1,111 @@
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/maahsome/gron"
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/yaml.v2"
+)
+
+type Jobs []Job
+
+type Job struct {
+	ID           int        `json:"id"`
+	Name         string     `json:"name"`
+	Stage        string     `json:"stage"`
+	Status       string     `json:"status"`
+	Ref          string     `json:"ref"`
+	Tag          bool       `json:"tag"`
+	AllowFailure bool       `json:"allow_failure"`
+	Duration     float64    `json:"duration"`
+	CreatedAt    time.Time  `json:"created_at"`
+	StartedAt    *time.Time `json:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at"`
+	WebURL       string     `json:"web_url"`
+	Pipeline     struct {
+		ID        int    `json:"id"`
+		ProjectID int    `json:"project_id"`
+		Ref       string `json:"ref"`
+		Sha       string `json:"sha"`
+		Status    string `json:"status"`
+	} `json:"pipeline"`
+}
+
+// ToJSON - Write the output as JSON
+func (j *Jobs) ToJSON() string {
+	jJSON, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		packageLogger().Error("Error extracting JSON", "error", err)
+		return ""
+	}
+	return string(jJSON[:])
+}
+
+func (j *Jobs) ToGRON() string {
+	jJSON, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		packageLogger().Error("Error extracting JSON for GRON", "error", err)
+	}
+	subReader := strings.NewReader(string(jJSON[:]))
+	subValues := &bytes.Buffer{}
+	ges := gron.NewGron(subReader, subValues)
+	ges.SetMonochrome(false)
+	if serr := ges.ToGron(); serr != nil {
+		packageLogger().Error("Problem generating GRON syntax", "error", serr)
+		return ""
+	}
+	return string(subValues.Bytes())
+}
+
+func (j *Jobs) ToYAML() string {
+	jYAML, err := yaml.Marshal(j)
+	if err != nil {
+		packageLogger().Error("Error extracting YAML", "error", err)
+		return ""
+	}
+	return string(jYAML[:])
+}
+
+func (j *Jobs) ToTEXT(noHeaders bool) string {
+	buf, _ := new(bytes.Buffer), make([]string, 0)
+
+	// ************************** TableWriter ******************************
+	table := tablewriter.NewWriter(buf)
+	if !noHeaders {
+		table.SetHeader([]string{"ID", "STAGE", "NAME", "STATUS", "DURATION"})
+		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	}
+
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(true)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetBorder(false)
+	table.SetTablePadding("\t") // pad with tabs
+	table.SetNoWhiteSpace(true)
+
+	for _, v := range *j {
+
+		row := []string{
+			fmt.Sprintf("%d", v.ID),
+			v.Stage,
+			v.Name,
+			v.Status,
+			fmt.Sprintf("%.0fs", v.Duration),
+		}
+		table.Append(row)
+	}
+
+	table.Render()
+
+	return buf.String()
+
+}
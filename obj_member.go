@@ -0,0 +1,338 @@
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maahsome/gron"
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/yaml.v2"
+)
+
+// AccessLevel is a member's role in a group or project
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/members.html#valid-access-levels
+type AccessLevel int
+
+const (
+	NoAccess         AccessLevel = 0
+	MinimalAccess    AccessLevel = 5
+	GuestAccess      AccessLevel = 10
+	ReporterAccess   AccessLevel = 20
+	DeveloperAccess  AccessLevel = 30
+	MaintainerAccess AccessLevel = 40
+	OwnerAccess      AccessLevel = 50
+)
+
+var accessLevelNames = map[AccessLevel]string{
+	NoAccess:         "No access",
+	MinimalAccess:    "Minimal access",
+	GuestAccess:      "Guest",
+	ReporterAccess:   "Reporter",
+	DeveloperAccess:  "Developer",
+	MaintainerAccess: "Maintainer",
+	OwnerAccess:      "Owner",
+}
+
+func (a AccessLevel) String() string {
+	if name, ok := accessLevelNames[a]; ok {
+		return name
+	}
+	return fmt.Sprintf("AccessLevel(%d)", int(a))
+}
+
+// ParseAccessLevel reads an access level name such as "developer" or
+// "Minimal access", or its number such as "30"
+func ParseAccessLevel(s string) (AccessLevel, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.Atoi(s); err == nil {
+		if _, ok := accessLevelNames[AccessLevel(n)]; ok {
+			return AccessLevel(n), nil
+		}
+		return NoAccess, fmt.Errorf("unknown access level %d", n)
+	}
+	normalized := strings.ToLower(strings.NewReplacer("_", " ", "-", " ").Replace(s))
+	for level, name := range accessLevelNames {
+		if strings.ToLower(name) == normalized {
+			return level, nil
+		}
+	}
+	return NoAccess, fmt.Errorf("unknown access level %q", s)
+}
+
+// UnmarshalYAML accepts an access level name or number, see
+// ParseAccessLevel
+func (a *AccessLevel) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	level, err := ParseAccessLevel(s)
+	if err != nil {
+		return err
+	}
+	*a = level
+	return nil
+}
+
+type Members []Member
+
+type Member struct {
+	ID          int         `json:"id"`
+	Username    string      `json:"username"`
+	Name        string      `json:"name"`
+	State       string      `json:"state"`
+	AvatarURL   string      `json:"avatar_url"`
+	WebURL      string      `json:"web_url"`
+	AccessLevel AccessLevel `json:"access_level"`
+	ExpiresAt   *ISODate    `json:"expires_at"`
+	CreatedAt   *time.Time  `json:"created_at,omitempty"`
+	// MembershipState is "awaiting" for invitations that are not accepted
+	// yet, "active" otherwise
+	MembershipState string `json:"membership_state,omitempty"`
+	// Bot is set for the users behind project and group access tokens
+	Bot bool `json:"bot,omitempty"`
+}
+
+// SharedGroup is a group a project or group is shared with
+type SharedGroup struct {
+	GroupID          int         `json:"group_id"`
+	GroupName        string      `json:"group_name"`
+	GroupFullPath    string      `json:"group_full_path"`
+	GroupAccessLevel AccessLevel `json:"group_access_level"`
+	ExpiresAt        *ISODate    `json:"expires_at"`
+}
+
+// MemberOptions sets a member's access level and expiry.  When updating, an
+// unset AccessLevel keeps the member's current level and a nil ExpiresAt
+// keeps the current expiry unless ClearExpiry is set.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/members.html#edit-a-member-of-a-group-or-project
+type MemberOptions struct {
+	AccessLevel AccessLevel `json:"access_level,omitempty"`
+	ExpiresAt   *ISODate    `json:"expires_at,omitempty"`
+	// ClearExpiry removes the member's expiry on update, ExpiresAt must be
+	// nil
+	ClearExpiry bool `json:"-"`
+}
+
+// addMemberRequest is the body of the group and project add member calls
+type addMemberRequest struct {
+	UserID      int         `json:"user_id"`
+	AccessLevel AccessLevel `json:"access_level"`
+	ExpiresAt   *ISODate    `json:"expires_at,omitempty"`
+}
+
+// updateMemberRequest is the body of the edit member calls.  GitLab requires
+// access_level, and clears the expiry when expires_at is "".
+type updateMemberRequest struct {
+	AccessLevel AccessLevel `json:"access_level"`
+	ExpiresAt   *string     `json:"expires_at,omitempty"`
+}
+
+// ProjectAccess - who can access a project, one entry per user
+type ProjectAccess []UserAccess
+
+// UserAccess is a user's effective access to a project and every
+// membership granting it
+type UserAccess struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	// AccessLevel is the highest level of Sources
+	AccessLevel AccessLevel    `json:"access_level"`
+	Sources     []AccessSource `json:"sources"`
+}
+
+// Access source kinds
+const (
+	// AccessDirect is a membership of the project itself
+	AccessDirect = "direct"
+	// AccessInherited is a membership of the project's group or one of
+	// its ancestors
+	AccessInherited = "inherited"
+	// AccessShared is a membership of a group the project, or one of its
+	// ancestor groups, is shared with
+	AccessShared = "shared"
+)
+
+// AccessSource is one membership granting a user access to a project
+type AccessSource struct {
+	// Kind is AccessDirect, AccessInherited or AccessShared
+	Kind string `json:"kind"`
+	// Path is the full path of the project or group the user is a member
+	// of
+	Path string `json:"path"`
+	// SharedWith is, for AccessShared, the full path of the project or
+	// group that Path was shared with
+	SharedWith string `json:"shared_with,omitempty"`
+	// AccessLevel is the level granted, for AccessShared the lower of the
+	// membership and the share
+	AccessLevel AccessLevel `json:"access_level"`
+	ExpiresAt   *ISODate    `json:"expires_at,omitempty"`
+}
+
+// ToJSON - Write the output as JSON
+func (ml *Members) ToJSON() string {
+	mlJSON, err := json.MarshalIndent(ml, "", "  ")
+	if err != nil {
+		packageLogger().Error("Error extracting JSON", "error", err)
+		return ""
+	}
+	return string(mlJSON[:])
+}
+
+func (ml *Members) ToGRON() string {
+	mlJSON, err := json.MarshalIndent(ml, "", "  ")
+	if err != nil {
+		packageLogger().Error("Error extracting JSON for GRON", "error", err)
+	}
+	subReader := strings.NewReader(string(mlJSON[:]))
+	subValues := &bytes.Buffer{}
+	ges := gron.NewGron(subReader, subValues)
+	ges.SetMonochrome(false)
+	if serr := ges.ToGron(); serr != nil {
+		packageLogger().Error("Problem generating GRON syntax", "error", serr)
+		return ""
+	}
+	return string(subValues.Bytes())
+}
+
+func (ml *Members) ToYAML() string {
+	mlYAML, err := yaml.Marshal(ml)
+	if err != nil {
+		packageLogger().Error("Error extracting YAML", "error", err)
+		return ""
+	}
+	return string(mlYAML[:])
+}
+
+func (ml *Members) ToTEXT(noHeaders bool) string {
+	buf, row := new(bytes.Buffer), make([]string, 0)
+
+	// ************************** TableWriter ******************************
+	table := tablewriter.NewWriter(buf)
+	if !noHeaders {
+		table.SetHeader([]string{"ID", "USERNAME", "NAME", "ACCESS", "EXPIRES"})
+		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	}
+
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(true)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetBorder(false)
+	table.SetTablePadding("\t") // pad with tabs
+	table.SetNoWhiteSpace(true)
+
+	for _, v := range *ml {
+		expires := ""
+		if v.ExpiresAt != nil {
+			expires = v.ExpiresAt.String()
+		}
+		row = []string{
+			fmt.Sprintf("%d", v.ID),
+			v.Username,
+			v.Name,
+			v.AccessLevel.String(),
+			expires,
+		}
+		table.Append(row)
+	}
+
+	table.Render()
+
+	return buf.String()
+
+}
+
+// ToJSON - Write the output as JSON
+func (pa *ProjectAccess) ToJSON() string {
+	paJSON, err := json.MarshalIndent(pa, "", "  ")
+	if err != nil {
+		packageLogger().Error("Error extracting JSON", "error", err)
+		return ""
+	}
+	return string(paJSON[:])
+}
+
+func (pa *ProjectAccess) ToGRON() string {
+	paJSON, err := json.MarshalIndent(pa, "", "  ")
+	if err != nil {
+		packageLogger().Error("Error extracting JSON for GRON", "error", err)
+	}
+	subReader := strings.NewReader(string(paJSON[:]))
+	subValues := &bytes.Buffer{}
+	ges := gron.NewGron(subReader, subValues)
+	ges.SetMonochrome(false)
+	if serr := ges.ToGron(); serr != nil {
+		packageLogger().Error("Problem generating GRON syntax", "error", serr)
+		return ""
+	}
+	return string(subValues.Bytes())
+}
+
+func (pa *ProjectAccess) ToYAML() string {
+	paYAML, err := yaml.Marshal(pa)
+	if err != nil {
+		packageLogger().Error("Error extracting YAML", "error", err)
+		return ""
+	}
+	return string(paYAML[:])
+}
+
+// ToTEXT - one row per user, listing every membership as
+// "path (Level)" or "path (Level, shared with other/path)"
+func (pa *ProjectAccess) ToTEXT(noHeaders bool) string {
+	buf, row := new(bytes.Buffer), make([]string, 0)
+
+	// ************************** TableWriter ******************************
+	table := tablewriter.NewWriter(buf)
+	if !noHeaders {
+		table.SetHeader([]string{"USERNAME", "NAME", "ACCESS", "GRANTED BY"})
+		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	}
+
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(true)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetBorder(false)
+	table.SetTablePadding("\t") // pad with tabs
+	table.SetNoWhiteSpace(true)
+
+	for _, v := range *pa {
+		sources := make([]string, 0, len(v.Sources))
+		for _, src := range v.Sources {
+			if src.SharedWith != "" {
+				sources = append(sources, fmt.Sprintf("%s (%s, shared with %s)", src.Path, src.AccessLevel, src.SharedWith))
+			} else {
+				sources = append(sources, fmt.Sprintf("%s (%s)", src.Path, src.AccessLevel))
+			}
+		}
+		row = []string{
+			v.Username,
+			v.Name,
+			v.AccessLevel.String(),
+			strings.Join(sources, ", "),
+		}
+		table.Append(row)
+	}
+
+	table.Render()
+
+	return buf.String()
+
+}
@@ -0,0 +1,29 @@
+package gitlab
+
+import "time"
+
+// createMergeRequestRequest is the body of CreateMergeRequest
+type createMergeRequestRequest struct {
+	ID                 int    `json:"id"`
+	Title              string `json:"title"`
+	Description        string `json:"description,omitempty"`
+	SourceBranch       string `json:"source_branch"`
+	TargetBranch       string `json:"target_branch"`
+	Squash             bool   `json:"squash"`
+	RemoveSourceBranch bool   `json:"remove_source_branch"`
+}
+
+type MergeRequest struct {
+	ID                int       `json:"id"`
+	IID               int       `json:"iid"`
+	ProjectID         int       `json:"project_id"`
+	Title             string    `json:"title"`
+	Description       string    `json:"description"`
+	State             string    `json:"state"`
+	SourceBranch      string    `json:"source_branch"`
+	TargetBranch      string    `json:"target_branch"`
+	Squash            bool      `json:"squash"`
+	ForceRemoveSource bool      `json:"force_remove_source_branch"`
+	WebURL            string    `json:"web_url"`
+	CreatedAt         time.Time `json:"created_at"`
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/maahsome/gron"
 	"github.com/muesli/termenv"
 	"github.com/olekukonko/tablewriter"
-	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 )
 
@@ -33,7 +32,7 @@ type Pipeline struct {
 func (pl *Pipelines) ToJSON() string {
 	plJSON, err := json.MarshalIndent(pl, "", "  ")
 	if err != nil {
-		logrus.WithError(err).Error("Error extracting JSON")
+		packageLogger().Error("Error extracting JSON", "error", err)
 		return ""
 	}
 	return string(plJSON[:])
@@ -42,14 +41,14 @@ func (pl *Pipelines) ToJSON() string {
 func (pl *Pipelines) ToGRON() string {
 	plJSON, err := json.MarshalIndent(pl, "", "  ")
 	if err != nil {
-		logrus.WithError(err).Error("Error extracting JSON for GRON")
+		packageLogger().Error("Error extracting JSON for GRON", "error", err)
 	}
 	subReader := strings.NewReader(string(plJSON[:]))
 	subValues := &bytes.Buffer{}
 	ges := gron.NewGron(subReader, subValues)
 	ges.SetMonochrome(false)
 	if serr := ges.ToGron(); serr != nil {
-		logrus.WithError(serr).Error("Problem generating GRON syntax")
+		packageLogger().Error("Problem generating GRON syntax", "error", serr)
 		return ""
 	}
 	return string(subValues.Bytes())
@@ -58,7 +57,7 @@ func (pl *Pipelines) ToGRON() string {
 func (pl *Pipelines) ToYAML() string {
 	plYAML, err := yaml.Marshal(pl)
 	if err != nil {
-		logrus.WithError(err).Error("Error extracting YAML")
+		packageLogger().Error("Error extracting YAML", "error", err)
 		return ""
 	}
 	return string(plYAML[:])
@@ -29,9 +29,10 @@ type Project struct {
 		ParentID int    `json:"parent_id"`
 		WebURL   string `json:"web_url"`
 	} `json:"namespace"`
-	Visibility string `json:"visibility"`
-	CreatorID  int    `json:"creator_id"`
-	Mirror     bool   `json:"mirror"`
+	Visibility       string        `json:"visibility"`
+	CreatorID        int           `json:"creator_id"`
+	Mirror           bool          `json:"mirror"`
+	SharedWithGroups []SharedGroup `json:"shared_with_groups"`
 }
 
 type ProtectedBranchSettings struct {
@@ -73,3 +74,47 @@ type ProjectMirror struct {
 	OnlyProtectedBranches  bool        `json:"only_protected_branches"`
 	KeepDivergentRefs      interface{} `json:"keep_divergent_refs"`
 }
+
+// ProjectGroupLink is a project's share with a group, as returned by
+// ShareProject
+type ProjectGroupLink struct {
+	ID          int         `json:"id"`
+	ProjectID   int         `json:"project_id"`
+	GroupID     int         `json:"group_id"`
+	GroupAccess AccessLevel `json:"group_access"`
+	ExpiresAt   *ISODate    `json:"expires_at"`
+}
+
+// createProjectRequest is the body of CreateProject
+type createProjectRequest struct {
+	Path                 string `json:"path"`
+	DefaultBranch        string `json:"default_branch"`
+	InitializeWithReadme bool   `json:"initialize_with_readme"`
+	Visibility           string `json:"visibility"`
+	NamespaceID          int    `json:"namespace_id"`
+}
+
+// protectBranchRequest is the body of ProtectBranch
+type protectBranchRequest struct {
+	Name                      string              `json:"name"`
+	PushAccessLevels          []branchAccessLevel `json:"push_access_levels"`
+	MergeAccessLevels         []branchAccessLevel `json:"merge_access_levels"`
+	AllowForcePush            bool                `json:"allow_force_push"`
+	UnprotectAccessLevels     []branchAccessLevel `json:"unprotect_access_levels"`
+	CodeOwnerApprovalRequired bool                `json:"code_owner_approval_required"`
+}
+
+type branchAccessLevel struct {
+	AccessLevel            int    `json:"access_level"`
+	AccessLevelDescription string `json:"access_level_description"`
+	UserID                 *int   `json:"user_id"`
+	GroupID                *int   `json:"group_id"`
+}
+
+// projectMirrorRequest is the body of CreateProjectMirror and
+// UpdateProjectMirror
+type projectMirrorRequest struct {
+	URL                   string `json:"url,omitempty"`
+	Enabled               bool   `json:"enabled"`
+	OnlyProtectedBranches bool   `json:"only_protected_branches"`
+}
@@ -0,0 +1,179 @@
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/maahsome/gron"
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/yaml.v2"
+)
+
+type UserList []User
+
+type User struct {
+	ID               int        `json:"id"`
+	Username         string     `json:"username"`
+	Name             string     `json:"name"`
+	State            string     `json:"state"`
+	Locked           bool       `json:"locked"`
+	AvatarURL        string     `json:"avatar_url"`
+	WebURL           string     `json:"web_url"`
+	CreatedAt        *time.Time `json:"created_at,omitempty"`
+	Bio              string     `json:"bio,omitempty"`
+	PublicEmail      string     `json:"public_email,omitempty"`
+	Email            string     `json:"email,omitempty"`
+	IsAdmin          bool       `json:"is_admin,omitempty"`
+	External         bool       `json:"external,omitempty"`
+	Bot              bool       `json:"bot,omitempty"`
+	LastSignInAt     *time.Time `json:"last_sign_in_at,omitempty"`
+	LastActivityOn   string     `json:"last_activity_on,omitempty"`
+	TwoFactorEnabled bool       `json:"two_factor_enabled,omitempty"`
+}
+
+// SSHKeys - a user's SSH keys
+type SSHKeys []SSHKey
+
+type SSHKey struct {
+	ID        int        `json:"id"`
+	Title     string     `json:"title"`
+	Key       string     `json:"key"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	UsageType string     `json:"usage_type,omitempty"`
+}
+
+// GPGKeys - a user's GPG keys
+type GPGKeys []GPGKey
+
+type GPGKey struct {
+	ID        int       `json:"id"`
+	Key       string    `json:"key"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UserMemberships - the groups and projects a user is a direct member of
+type UserMemberships []UserMembership
+
+type UserMembership struct {
+	SourceID   int    `json:"source_id"`
+	SourceName string `json:"source_name"`
+	// SourceType is "Project" or "Namespace" (a group)
+	SourceType  string      `json:"source_type"`
+	AccessLevel AccessLevel `json:"access_level"`
+}
+
+// ToJSON - Write the output as JSON
+func (ul *UserList) ToJSON() string {
+	ulJSON, err := json.MarshalIndent(ul, "", "  ")
+	if err != nil {
+		packageLogger().Error("Error extracting JSON", "error", err)
+		return ""
+	}
+	return string(ulJSON[:])
+}
+
+func (ul *UserList) ToGRON() string {
+	ulJSON, err := json.MarshalIndent(ul, "", "  ")
+	if err != nil {
+		packageLogger().Error("Error extracting JSON for GRON", "error", err)
+	}
+	subReader := strings.NewReader(string(ulJSON[:]))
+	subValues := &bytes.Buffer{}
+	ges := gron.NewGron(subReader, subValues)
+	ges.SetMonochrome(false)
+	if serr := ges.ToGron(); serr != nil {
+		packageLogger().Error("Problem generating GRON syntax", "error", serr)
+		return ""
+	}
+	return string(subValues.Bytes())
+}
+
+func (ul *UserList) ToYAML() string {
+	ulYAML, err := yaml.Marshal(ul)
+	if err != nil {
+		packageLogger().Error("Error extracting YAML", "error", err)
+		return ""
+	}
+	return string(ulYAML[:])
+}
+
+func (ul *UserList) ToTEXT(noHeaders bool) string {
+	buf, row := new(bytes.Buffer), make([]string, 0)
+
+	// ************************** TableWriter ******************************
+	table := tablewriter.NewWriter(buf)
+	if !noHeaders {
+		table.SetHeader([]string{"ID", "USERNAME", "NAME", "STATE"})
+		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	}
+
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(true)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetBorder(false)
+	table.SetTablePadding("\t") // pad with tabs
+	table.SetNoWhiteSpace(true)
+
+	for _, v := range *ul {
+		row = []string{
+			fmt.Sprintf("%d", v.ID),
+			v.Username,
+			v.Name,
+			v.State,
+		}
+		table.Append(row)
+	}
+
+	table.Render()
+
+	return buf.String()
+
+}
+
+// ToJSON - Write the output as JSON
+func (u *User) ToJSON() string {
+	uJSON, err := json.MarshalIndent(u, "", "  ")
+	if err != nil {
+		packageLogger().Error("Error extracting JSON", "error", err)
+		return ""
+	}
+	return string(uJSON[:])
+}
+
+func (u *User) ToGRON() string {
+	uJSON, err := json.MarshalIndent(u, "", "  ")
+	if err != nil {
+		packageLogger().Error("Error extracting JSON for GRON", "error", err)
+	}
+	subReader := strings.NewReader(string(uJSON[:]))
+	subValues := &bytes.Buffer{}
+	ges := gron.NewGron(subReader, subValues)
+	ges.SetMonochrome(false)
+	if serr := ges.ToGron(); serr != nil {
+		packageLogger().Error("Problem generating GRON syntax", "error", serr)
+		return ""
+	}
+	return string(subValues.Bytes())
+}
+
+func (u *User) ToYAML() string {
+	uYAML, err := yaml.Marshal(u)
+	if err != nil {
+		packageLogger().Error("Error extracting YAML", "error", err)
+		return ""
+	}
+	return string(uYAML[:])
+}
+
+func (u *User) ToTEXT(noHeaders bool) string {
+	ul := UserList{*u}
+	return ul.ToTEXT(noHeaders)
+}
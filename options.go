@@ -0,0 +1,222 @@
+package gitlab
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// ClientOption configures the client built by NewClient
+type ClientOption func(*clientConfig) error
+
+type clientConfig struct {
+	baseUrl   string
+	apiPath   string
+	token     string
+	caPEM     [][]byte
+	certs     []tls.Certificate
+	insecure  bool
+	proxy     string
+	timeout   time.Duration
+	userAgent string
+
+	credentials CredentialsProvider
+
+	retryCount       int
+	retryMinWait     time.Duration
+	retryMaxWait     time.Duration
+	rateLimitFloor   int
+	throttleObserver func(ThrottleEvent)
+
+	responseObservers []func(ResponseMeta)
+	logger            Logger
+	tracer            Tracer
+	metrics           Metrics
+	cache             Cache
+	cacheTTL          time.Duration
+	dryRun            *DryRun
+	auditSink         AuditSink
+
+	// transports wrap the HTTP transport, the first added is outermost
+	transports []func(http.RoundTripper) http.RoundTripper
+}
+
+// WithBaseURL sets the GitLab server.  Either a bare host ("gitlab.com",
+// https is assumed) or a full URL with any scheme and an optional relative
+// root ("http://localhost:8080", "https://example.com/gitlab")
+func WithBaseURL(baseUrl string) ClientOption {
+	return func(c *clientConfig) error {
+		c.baseUrl = baseUrl
+		return nil
+	}
+}
+
+// WithAPIPath overrides the default "/api/v4" API path
+func WithAPIPath(apiPath string) ClientOption {
+	return func(c *clientConfig) error {
+		c.apiPath = apiPath
+		return nil
+	}
+}
+
+// WithToken sets the personal/project/group access token, sent as
+// PRIVATE-TOKEN.  See WithCredentials for other kinds of token.
+func WithToken(token string) ClientOption {
+	return func(c *clientConfig) error {
+		c.token = token
+		c.credentials = nil
+		return nil
+	}
+}
+
+// WithCACertFile trusts the PEM encoded CA bundle at path in addition to the
+// system roots
+func WithCACertFile(path string) ClientOption {
+	return func(c *clientConfig) error {
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading CA bundle %s: %w", path, err)
+		}
+		c.caPEM = append(c.caPEM, pem)
+		return nil
+	}
+}
+
+// WithCACertPEM trusts the PEM encoded CA bundle in addition to the system
+// roots
+func WithCACertPEM(pem []byte) ClientOption {
+	return func(c *clientConfig) error {
+		c.caPEM = append(c.caPEM, pem)
+		return nil
+	}
+}
+
+// WithClientCertificate presents cert for mutual TLS
+func WithClientCertificate(cert tls.Certificate) ClientOption {
+	return func(c *clientConfig) error {
+		c.certs = append(c.certs, cert)
+		return nil
+	}
+}
+
+// WithClientCertificateFiles loads a PEM encoded certificate and key pair
+// and presents it for mutual TLS
+func WithClientCertificateFiles(certFile, keyFile string) ClientOption {
+	return func(c *clientConfig) error {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("loading client certificate %s: %w", certFile, err)
+		}
+		c.certs = append(c.certs, cert)
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify disables server certificate verification, possibly
+// exposing your system to MITM attack
+func WithInsecureSkipVerify() ClientOption {
+	return func(c *clientConfig) error {
+		c.insecure = true
+		return nil
+	}
+}
+
+// WithProxy sends every request through the HTTP(S) proxy at proxyURL
+func WithProxy(proxyURL string) ClientOption {
+	return func(c *clientConfig) error {
+		c.proxy = proxyURL
+		return nil
+	}
+}
+
+// WithTimeout bounds each individual HTTP request, including reading the
+// response body
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *clientConfig) error {
+		if timeout < 0 {
+			return errors.New("timeout must not be negative")
+		}
+		c.timeout = timeout
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with each request
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *clientConfig) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// transport layers the configured wrappers over base.  Outermost first:
+// dry run, audit, cache, tracing and metrics, then the WithCassette style wrappers
+// in the order their options were given.
+func (c *clientConfig) transport(base http.RoundTripper, logger Logger) http.RoundTripper {
+
+	transport := base
+	for i := len(c.transports) - 1; i >= 0; i-- {
+		transport = c.transports[i](transport)
+	}
+	if c.tracer != nil || c.metrics != nil {
+		transport = &instrumentedTransport{
+			next:    transport,
+			apiPath: c.apiPath,
+			tracer:  c.tracer,
+			metrics: c.metrics,
+		}
+	}
+	if c.cache != nil {
+		transport = &cachingTransport{
+			next:    transport,
+			cache:   c.cache,
+			ttl:     c.cacheTTL,
+			apiPath: c.apiPath,
+			now:     time.Now,
+		}
+	}
+	if c.auditSink != nil {
+		transport = &auditingTransport{
+			next:    transport,
+			sink:    c.auditSink,
+			apiPath: c.apiPath,
+			logger:  logger,
+		}
+	}
+	if c.dryRun != nil {
+		transport = &dryRunTransport{
+			next:    transport,
+			dryRun:  c.dryRun,
+			apiPath: c.apiPath,
+			logger:  logger,
+		}
+	}
+	return transport
+}
+
+func (c *clientConfig) tlsConfig() (*tls.Config, error) {
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.insecure,
+		Certificates:       c.certs,
+	}
+
+	if len(c.caPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		for _, pem := range c.caPEM {
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, errors.New("no certificates found in CA bundle")
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
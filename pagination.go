@@ -0,0 +1,255 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ErrStopPagination can be returned from a Paginate callback to stop
+// walking pages without Paginate returning an error
+var ErrStopPagination = errors.New("stop pagination")
+
+// PagerOptions control how a list endpoint is walked
+type PagerOptions struct {
+	// PerPage sets per_page unless the uri already has it, 0 leaves the
+	// GitLab default
+	PerPage int
+	// MaxItems stops the walk after this many items, 0 means no cap
+	MaxItems int
+	// Keyset requests keyset pagination (pagination=keyset, ordered by id
+	// unless the uri sets order_by), which GitLab follows with Link headers
+	Keyset bool
+}
+
+// PageIterator walks a GitLab list endpoint one item at a time, fetching
+// the next page only when the current one is exhausted
+//
+//	it := client.Iterate(ctx, "/groups/42/projects", nil)
+//	for it.Next() {
+//		var p gitlab.Project
+//		if err := it.Decode(&p); err != nil { ... }
+//	}
+//	if err := it.Err(); err != nil { ... }
+type PageIterator struct {
+	client   *gitlabClient
+	ctx      context.Context
+	next     string
+	maxItems int
+	count    int
+	page     []json.RawMessage
+	pos      int
+	item     json.RawMessage
+	err      error
+}
+
+// Next advances to the next item, returning false at the end of the list,
+// on error, or once MaxItems items have been returned
+func (it *PageIterator) Next() bool {
+	for {
+		if it.err != nil || (it.maxItems > 0 && it.count >= it.maxItems) {
+			return false
+		}
+		if it.pos < len(it.page) {
+			it.item = it.page[it.pos]
+			it.pos++
+			it.count++
+			return true
+		}
+		if it.next == "" || it.client == nil {
+			return false
+		}
+		it.err = it.fetch()
+	}
+}
+
+// Item returns the raw JSON of the current item
+func (it *PageIterator) Item() json.RawMessage {
+	return it.item
+}
+
+// Decode unmarshals the current item into v
+func (it *PageIterator) Decode(v interface{}) error {
+	return decodeJSON(it.item, v)
+}
+
+// Err returns the error, if any, that stopped the iteration
+func (it *PageIterator) Err() error {
+	return it.err
+}
+
+func (it *PageIterator) fetch() error {
+
+	if err := it.ctx.Err(); err != nil {
+		return err
+	}
+
+	current := it.next
+	resp, err := it.client.do(it.ctx, http.MethodGet, current, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	var page []json.RawMessage
+	if err := decodeJSON(resp.Body(), &page); err != nil {
+		return err
+	}
+
+	it.page, it.pos = page, 0
+	it.next = ""
+	if len(page) > 0 {
+		it.next = nextPageURI(current, resp)
+	}
+	return nil
+}
+
+// Iterate returns an iterator over every item of the list endpoint uri
+func (r *gitlabClient) Iterate(ctx context.Context, uri string, opts *PagerOptions) *PageIterator {
+
+	if opts == nil {
+		opts = &PagerOptions{}
+	}
+	if opts.PerPage > 0 {
+		uri = setQueryDefault(uri, "per_page", strconv.Itoa(opts.PerPage))
+	}
+	if opts.Keyset {
+		uri = setQueryDefault(uri, "pagination", "keyset")
+		uri = setQueryDefault(uri, "order_by", "id")
+		uri = setQueryDefault(uri, "sort", "asc")
+	}
+
+	return &PageIterator{
+		client:   r,
+		ctx:      ctx,
+		next:     uri,
+		maxItems: opts.MaxItems,
+	}
+}
+
+// Paginate streams every item of the list endpoint uri to fn, one page in
+// memory at a time.  Returning ErrStopPagination from fn ends the walk
+// early without error.
+func (r *gitlabClient) Paginate(ctx context.Context, uri string, opts *PagerOptions, fn func(item json.RawMessage) error) error {
+
+	it := r.Iterate(ctx, uri, opts)
+	for it.Next() {
+		if err := fn(it.Item()); err != nil {
+			if errors.Is(err, ErrStopPagination) {
+				return nil
+			}
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// listAll decodes every item of uri and appends it to the slice out points to
+func (r *gitlabClient) listAll(ctx context.Context, uri string, opts *PagerOptions, out interface{}) error {
+
+	slice := reflect.ValueOf(out)
+	if slice.Kind() != reflect.Ptr || slice.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("listAll: out must be a pointer to a slice, got %T", out)
+	}
+	slice = slice.Elem()
+	elemType := slice.Type().Elem()
+
+	return r.Paginate(ctx, uri, opts, func(item json.RawMessage) error {
+		elem := reflect.New(elemType)
+		if err := decodeJSON(item, elem.Interface()); err != nil {
+			return err
+		}
+		slice.Set(reflect.Append(slice, elem.Elem()))
+		return nil
+	})
+}
+
+// appendPages decodes first, the response to uri, into the slice out points
+// to and appends the items of every following page
+func (r *gitlabClient) appendPages(ctx context.Context, uri string, first *resty.Response, out interface{}) error {
+
+	if err := decodeJSON(first.Body(), out); err != nil {
+		return err
+	}
+	if reflect.ValueOf(out).Elem().Len() == 0 {
+		return nil
+	}
+	if next := nextPageURI(uri, first); next != "" {
+		return r.listAll(ctx, next, nil, out)
+	}
+	return nil
+}
+
+func isSlicePointer(out interface{}) bool {
+	v := reflect.ValueOf(out)
+	return v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Slice
+}
+
+func isJSONArray(body []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(body), []byte("["))
+}
+
+// nextPageURI works out the next page from X-Next-Page, falling back to the
+// Link header (keyset pagination and large collections omit X-Next-Page /
+// X-Total-Pages).  It returns "" on the last page.
+func nextPageURI(current string, resp *resty.Response) string {
+
+	if nextPage := resp.Header().Get("X-Next-Page"); nextPage != "" {
+		return setQuery(current, "page", nextPage)
+	}
+	if link := linkNext(resp.Header().Get("Link")); link != "" {
+		return link
+	}
+	return ""
+}
+
+// linkNext extracts the rel="next" target from an RFC 8288 Link header
+func linkNext(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		segments := strings.Split(part, ";")
+		if len(segments) < 2 {
+			continue
+		}
+		target := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		for _, param := range segments[1:] {
+			param = strings.ReplaceAll(strings.TrimSpace(param), " ", "")
+			if param == `rel="next"` || param == "rel=next" {
+				return strings.Trim(target, "<>")
+			}
+		}
+	}
+	return ""
+}
+
+// setQuery sets key=value in the query string of uri
+func setQuery(uri, key, value string) string {
+	path, rawQuery := uri, ""
+	if i := strings.Index(uri, "?"); i >= 0 {
+		path, rawQuery = uri[:i], uri[i+1:]
+	}
+	query, _ := url.ParseQuery(rawQuery)
+	query.Set(key, value)
+	return fmt.Sprintf("%s?%s", path, query.Encode())
+}
+
+// setQueryDefault sets key=value unless uri already carries key
+func setQueryDefault(uri, key, value string) string {
+	if i := strings.Index(uri, "?"); i >= 0 {
+		query, _ := url.ParseQuery(uri[i+1:])
+		if _, ok := query[key]; ok {
+			return uri
+		}
+	}
+	return setQuery(uri, key, value)
+}
@@ -0,0 +1,56 @@
+package gitlab_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	gitlab "github.com/maahsome/gitlab-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPagination(t *testing.T) {
+
+	fake, client := newFakeClient(t)
+	for i := 1; i <= 45; i++ {
+		fake.AddGroup(gitlab.Group{Path: fmt.Sprintf("group-%02d", i), Name: fmt.Sprintf("Group %02d", i)})
+	}
+
+	ctx, rec := gitlab.RecordResponseMeta(context.Background())
+	groups, err := client.ListGroupsWithContext(ctx, &gitlab.ListGroupsOptions{PaginationOptions: gitlab.PaginationOptions{PerPage: 20}})
+	require.NoError(t, err)
+	assert.Len(t, groups, 45)
+	metas := rec.All()
+	require.Len(t, metas, 3)
+	assert.Equal(t, 45, metas[0].Total)
+	assert.Equal(t, 3, metas[0].TotalPages)
+	assert.Equal(t, 2, metas[0].NextPage)
+	assert.Zero(t, metas[2].NextPage)
+
+	capped, err := client.ListGroups(&gitlab.ListGroupsOptions{PaginationOptions: gitlab.PaginationOptions{PerPage: 20, MaxItems: 30}})
+	require.NoError(t, err)
+	assert.Len(t, capped, 30)
+
+	it := client.Iterate(context.Background(), "/groups", &gitlab.PagerOptions{PerPage: 10, Keyset: true})
+	seen := 0
+	for it.Next() {
+		var g gitlab.Group
+		require.NoError(t, it.Decode(&g))
+		seen++
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, 45, seen)
+
+	visited := 0
+	err = client.Paginate(context.Background(), "/groups", &gitlab.PagerOptions{PerPage: 10}, func(item json.RawMessage) error {
+		visited++
+		if visited == 12 {
+			return gitlab.ErrStopPagination
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 12, visited)
+}
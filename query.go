@@ -0,0 +1,167 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// encodeQuery turns an options struct into query parameters using its
+// `url:"name[,omitempty][,brackets]"` tags.  Embedded structs are
+// flattened, nil pointers are always omitted (so *bool can send an explicit
+// false) and slices repeat the key, as key[] when tagged with brackets.
+// url.Values are copied as is.
+func encodeQuery(opts interface{}) (url.Values, error) {
+
+	values := url.Values{}
+	switch q := opts.(type) {
+	case nil:
+		return values, nil
+	case url.Values:
+		for k, v := range q {
+			values[k] = append([]string(nil), v...)
+		}
+		return values, nil
+	}
+	v := reflect.ValueOf(opts)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return values, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("query options must be a struct, got %T", opts)
+	}
+
+	return values, encodeStruct(values, v)
+}
+
+func encodeStruct(values url.Values, v reflect.Value) error {
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+		tag := field.Tag.Get("url")
+
+		if field.Anonymous && tag == "" {
+			for fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					break
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				if err := encodeStruct(values, fv); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if tag == "" || tag == "-" || field.PkgPath != "" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		name := parts[0]
+		omitEmpty, brackets := false, false
+		for _, opt := range parts[1:] {
+			switch opt {
+			case "omitempty":
+				omitEmpty = true
+			case "brackets":
+				brackets = true
+			}
+		}
+
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		} else if omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		if fv.Kind() == reflect.Slice {
+			if brackets {
+				name += "[]"
+			}
+			for j := 0; j < fv.Len(); j++ {
+				s, err := queryValue(fv.Index(j))
+				if err != nil {
+					return fmt.Errorf("%s: %w", field.Name, err)
+				}
+				values.Add(name, s)
+			}
+			continue
+		}
+
+		s, err := queryValue(fv)
+		if err != nil {
+			return fmt.Errorf("%s: %w", field.Name, err)
+		}
+		values.Set(name, s)
+	}
+	return nil
+}
+
+func queryValue(v reflect.Value) (string, error) {
+
+	if t, ok := v.Interface().(time.Time); ok {
+		return t.Format(time.RFC3339), nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	}
+	return "", fmt.Errorf("unsupported query type %s", v.Type())
+}
+
+// withQuery appends the encoded opts to uri
+func withQuery(uri string, opts interface{}) (string, error) {
+
+	values, err := encodeQuery(opts)
+	if err != nil {
+		return "", err
+	}
+	if len(values) == 0 {
+		return uri, nil
+	}
+	if strings.Contains(uri, "?") {
+		return fmt.Sprintf("%s&%s", uri, values.Encode()), nil
+	}
+	return fmt.Sprintf("%s?%s", uri, values.Encode()), nil
+}
+
+// pathID formats a project or group identifier as a single URL path
+// segment.  id may be numeric or a namespaced path ("group/sub/project"),
+// which is escaped so the slashes stay inside the segment.
+func pathID(id string) (string, error) {
+
+	if id == "" {
+		return "", fmt.Errorf("empty project or group path")
+	}
+	return pathEscape(id), nil
+}
+
+// pathEscape escapes s (a namespaced path, branch name, wildcard branch
+// pattern or repository file path) as a single URL path segment.  Dots are
+// escaped too so GitLab never mistakes a trailing ".json" or ".yml" for a
+// response format.  s is always escaped exactly once, so callers pass raw
+// paths: a literal "%" in "100%done.txt" is sent as "%25".
+func pathEscape(s string) string {
+	return strings.ReplaceAll(url.PathEscape(s), ".", "%2E")
+}
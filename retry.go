@@ -0,0 +1,274 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	defaultRetryCount     = 3
+	defaultRetryMinWait   = 500 * time.Millisecond
+	defaultRetryMaxWait   = 60 * time.Second
+	defaultRateLimitFloor = 5
+)
+
+// ThrottleEventKind tells why the client slowed down
+type ThrottleEventKind string
+
+const (
+	// ThrottleEventWait - the client paused before sending a request because
+	// RateLimit-Remaining dropped to the configured floor
+	ThrottleEventWait ThrottleEventKind = "wait"
+	// ThrottleEventRetry - a request failed with 429/502/503/504 or a
+	// transport error and is about to be retried
+	ThrottleEventRetry ThrottleEventKind = "retry"
+)
+
+// ThrottleEvent is passed to the observer set with WithThrottleObserver
+type ThrottleEvent struct {
+	Kind       ThrottleEventKind
+	Method     string
+	Url        string
+	StatusCode int
+	Attempt    int
+	Wait       time.Duration
+	Remaining  int
+	Reset      time.Time
+	Err        error
+}
+
+// WithRetry retries idempotent requests (GET, HEAD, PUT, DELETE) up to
+// maxRetries times on 429/502/503/504 responses and transport errors,
+// backing off exponentially with jitter between minWait and maxWait.  A
+// Retry-After or RateLimit-Reset header takes precedence over the backoff.
+// maxRetries of 0 disables retries.
+func WithRetry(maxRetries int, minWait, maxWait time.Duration) ClientOption {
+	return func(c *clientConfig) error {
+		if maxRetries < 0 || minWait < 0 || maxWait < minWait {
+			return errors.New("invalid retry settings")
+		}
+		c.retryCount = maxRetries
+		c.retryMinWait = minWait
+		c.retryMaxWait = maxWait
+		return nil
+	}
+}
+
+// WithRateLimitFloor makes the client wait for the RateLimit-Reset time once
+// RateLimit-Remaining drops to floor.  A negative floor disables waiting.
+func WithRateLimitFloor(floor int) ClientOption {
+	return func(c *clientConfig) error {
+		c.rateLimitFloor = floor
+		return nil
+	}
+}
+
+// WithThrottleObserver registers fn to be called whenever the client waits
+// for the rate limit to reset or retries a request
+func WithThrottleObserver(fn func(ThrottleEvent)) ClientOption {
+	return func(c *clientConfig) error {
+		c.throttleObserver = fn
+		return nil
+	}
+}
+
+// rateLimiter tracks GitLab's RateLimit-* response headers
+type rateLimiter struct {
+	mu        sync.Mutex
+	remaining int
+	reset     time.Time
+	floor     int
+	observer  func(ThrottleEvent)
+}
+
+func newRateLimiter(floor int, observer func(ThrottleEvent)) *rateLimiter {
+	return &rateLimiter{
+		remaining: -1,
+		floor:     floor,
+		observer:  observer,
+	}
+}
+
+// beforeRequest blocks until the rate limit resets when the remaining quota
+// is at the floor.  In-flight requests count against the quota.
+func (l *rateLimiter) beforeRequest(c *resty.Client, req *resty.Request) error {
+
+	l.mu.Lock()
+	var wait time.Duration
+	remaining, reset := l.remaining, l.reset
+	if l.floor >= 0 && remaining >= 0 && remaining <= l.floor {
+		wait = time.Until(reset)
+	}
+	if remaining > 0 {
+		l.remaining--
+	}
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	l.notify(ThrottleEvent{
+		Kind:      ThrottleEventWait,
+		Method:    req.Method,
+		Url:       req.URL,
+		Attempt:   req.Attempt,
+		Wait:      wait,
+		Remaining: remaining,
+		Reset:     reset,
+	})
+	return sleepContext(req.Context(), wait)
+}
+
+func (l *rateLimiter) afterResponse(c *resty.Client, resp *resty.Response) error {
+
+	remaining, err := strconv.Atoi(resp.Header().Get("RateLimit-Remaining"))
+	if err != nil {
+		return nil
+	}
+	reset, err := strconv.ParseInt(resp.Header().Get("RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	l.remaining = remaining
+	l.reset = time.Unix(reset, 0)
+	l.mu.Unlock()
+	return nil
+}
+
+// retryAfter picks the wait before the next attempt and reports it
+func (l *rateLimiter) retryAfter(minWait, maxWait time.Duration) resty.RetryAfterFunc {
+	return func(c *resty.Client, resp *resty.Response) (time.Duration, error) {
+
+		wait := serverRetryAfter(resp)
+		if wait <= 0 {
+			wait = jitteredBackoff(minWait, maxWait, resp.Request.Attempt)
+		}
+		if wait > maxWait {
+			wait = maxWait
+		}
+
+		l.notify(ThrottleEvent{
+			Kind:       ThrottleEventRetry,
+			Method:     resp.Request.Method,
+			Url:        resp.Request.URL,
+			StatusCode: resp.StatusCode(),
+			Attempt:    resp.Request.Attempt,
+			Wait:       wait,
+			Remaining:  l.currentRemaining(),
+			Err:        checkResponse(resp),
+		})
+		return wait, nil
+	}
+}
+
+func (l *rateLimiter) currentRemaining() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.remaining
+}
+
+func (l *rateLimiter) notify(ev ThrottleEvent) {
+	if l.observer != nil {
+		l.observer(ev)
+	}
+}
+
+// shouldRetry retries idempotent requests on throttling, gateway errors and
+// transport failures, but never once the caller's context is done or for
+// requests missing from a replayed cassette
+func shouldRetry(resp *resty.Response, err error) bool {
+
+	if resp == nil || resp.Request == nil {
+		return false
+	}
+	if resp.Request.Context().Err() != nil {
+		return false
+	}
+	switch resp.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+	default:
+		return false
+	}
+	if err != nil {
+		return !isCassetteMiss(err)
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// serverRetryAfter honours Retry-After (seconds or HTTP date) and, on a 429,
+// RateLimit-Reset (unix seconds)
+func serverRetryAfter(resp *resty.Response) time.Duration {
+
+	if resp.RawResponse == nil {
+		return 0
+	}
+
+	if ra := resp.Header().Get("Retry-After"); ra != "" {
+		if secs, err := strconv.Atoi(ra); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+		if at, err := http.ParseTime(ra); err == nil {
+			return time.Until(at)
+		}
+	}
+
+	if resp.StatusCode() == http.StatusTooManyRequests {
+		if reset, err := strconv.ParseInt(resp.Header().Get("RateLimit-Reset"), 10, 64); err == nil {
+			return time.Until(time.Unix(reset, 0))
+		}
+	}
+	return 0
+}
+
+var (
+	jitterMu   sync.Mutex
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// jitteredBackoff returns minWait*2^(attempt-1) capped at maxWait, with the
+// upper half randomised so concurrent clients do not retry in lock step
+func jitteredBackoff(minWait, maxWait time.Duration, attempt int) time.Duration {
+
+	backoff := minWait
+	for i := 1; i < attempt && backoff < maxWait; i++ {
+		backoff *= 2
+	}
+	if backoff > maxWait {
+		backoff = maxWait
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	half := backoff / 2
+	jitterMu.Lock()
+	jitter := time.Duration(jitterRand.Int63n(int64(half) + 1))
+	jitterMu.Unlock()
+	return half + jitter
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
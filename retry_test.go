@@ -0,0 +1,150 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// retryServer answers with statuses in turn, repeating the last one, and
+// counts the requests it gets
+type retryServer struct {
+	mu       sync.Mutex
+	statuses []int
+	header   http.Header
+	requests int
+}
+
+func (s *retryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	status := s.statuses[len(s.statuses)-1]
+	if s.requests < len(s.statuses) {
+		status = s.statuses[s.requests]
+	}
+	s.requests++
+	s.mu.Unlock()
+	for k, v := range s.header {
+		w.Header()[k] = v
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write([]byte(`{"id":1}`))
+}
+
+// newRetryClient returns a client of a retryServer answering statuses,
+// retrying up to 3 times between 1ms and 20ms, and the throttle events it
+// reports
+func newRetryClient(t *testing.T, statuses ...int) (*retryServer, GitlabClient, *[]ThrottleEvent) {
+	t.Helper()
+	s := &retryServer{statuses: statuses}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	var (
+		mu     sync.Mutex
+		events []ThrottleEvent
+	)
+	client, err := NewClient(
+		WithBaseURL(srv.URL),
+		WithToken("token"),
+		WithLogger(NopLogger()),
+		WithRetry(3, time.Millisecond, 20*time.Millisecond),
+		WithThrottleObserver(func(ev ThrottleEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, ev)
+		}),
+	)
+	require.NoError(t, err)
+	return s, client, &events
+}
+
+func TestRetryIdempotentRequests(t *testing.T) {
+
+	s, client, events := newRetryClient(t, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK)
+
+	_, err := client.GetProject(1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, s.requests)
+	require.Len(t, *events, 2)
+	for i, ev := range *events {
+		assert.Equal(t, ThrottleEventRetry, ev.Kind)
+		assert.Equal(t, http.MethodGet, ev.Method)
+		assert.Equal(t, i+1, ev.Attempt)
+		assert.True(t, ev.Wait <= 20*time.Millisecond, "wait %s", ev.Wait)
+	}
+	assert.Equal(t, http.StatusServiceUnavailable, (*events)[0].StatusCode)
+	assert.Equal(t, http.StatusBadGateway, (*events)[1].StatusCode)
+}
+
+func TestRetryGivesUp(t *testing.T) {
+
+	s, client, _ := newRetryClient(t, http.StatusGatewayTimeout)
+
+	_, err := client.GetProject(1)
+	var re *RequestError
+	require.True(t, errors.As(err, &re), "got %v", err)
+	assert.Equal(t, http.StatusGatewayTimeout, re.StatusCode)
+	assert.Equal(t, 4, s.requests, "the first attempt and 3 retries")
+}
+
+func TestRetrySkipsNonIdempotentRequests(t *testing.T) {
+
+	s, client, events := newRetryClient(t, http.StatusServiceUnavailable)
+	err := client.Post("/projects", map[string]string{"name": "site"}, nil)
+	require.Error(t, err)
+	assert.Equal(t, 1, s.requests, "a POST is not retried")
+	assert.Empty(t, *events)
+
+	s, client, _ = newRetryClient(t, http.StatusNotFound)
+	_, err = client.GetProject(404)
+	assert.True(t, IsNotFound(err))
+	assert.Equal(t, 1, s.requests, "a 404 is not retried")
+}
+
+func TestRetryHonoursRetryAfter(t *testing.T) {
+
+	s, client, events := newRetryClient(t, http.StatusTooManyRequests, http.StatusOK)
+	s.header = http.Header{"Retry-After": {"30"}}
+
+	_, err := client.GetProject(1)
+	require.NoError(t, err)
+	require.Len(t, *events, 1)
+	assert.Equal(t, http.StatusTooManyRequests, (*events)[0].StatusCode)
+	assert.Equal(t, 20*time.Millisecond, (*events)[0].Wait, "Retry-After, capped at the maximum wait")
+}
+
+func TestRetryStopsWithContext(t *testing.T) {
+
+	s, client, _ := newRetryClient(t, http.StatusServiceUnavailable)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.GetProjectWithContext(ctx, 1)
+	require.Error(t, err)
+	assert.True(t, s.requests <= 1, "%d requests", s.requests)
+}
+
+func TestJitteredBackoff(t *testing.T) {
+
+	for attempt, want := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	} {
+		for i := 0; i < 20; i++ {
+			got := jitteredBackoff(100*time.Millisecond, time.Second, attempt)
+			assert.True(t, got >= want/2 && got <= want, "attempt %d: %s", attempt, got)
+		}
+	}
+	assert.Zero(t, jitteredBackoff(0, 0, 1))
+}
@@ -0,0 +1,214 @@
+package gitlab
+
+import "context"
+
+// The GitLab API grouped by resource.  GitlabClient hands each service out
+// through an accessor, for code that only needs part of the API:
+//
+//	func lastGreen(pipelines gitlab.PipelinesService, projectID int) (gitlab.Pipeline, error)
+//	...
+//	lastGreen(client.Pipelines(), 42)
+//
+// GitlabClient's flat methods are frozen: new calls, and new variants of
+// existing ones, are only added to the services.  Each service has a
+// testify mock, e.g. PipelinesMock, see mock_services.go.
+
+//go:generate go run ./internal/mockgen -interface ProjectsService,GroupsService,MembersService,PipelinesService,JobsService,VariablesService,MergeRequestsService,RepositoryFilesService,UsersService -out mock_services.go
+
+// ProjectsService - projects, their protected branches, remote mirrors and
+// shares
+type ProjectsService interface {
+	GetProject(projectID int) (Project, error)
+	GetProjectWithContext(ctx context.Context, projectID int) (Project, error)
+	GetProjectID(projectPath string) (int, error)
+	GetProjectIDWithContext(ctx context.Context, projectPath string) (int, error)
+	CreateProject(groupID int, projectPath string, visibility string) (Project, error)
+	CreateProjectWithContext(ctx context.Context, groupID int, projectPath string, visibility string) (Project, error)
+	DeleteProject(projectID int) error
+	DeleteProjectWithContext(ctx context.Context, projectID int) error
+	ShareProject(projectID int, sharedWithGroupID int, accessLevel AccessLevel, expiresAt *ISODate) (ProjectGroupLink, error)
+	ShareProjectWithContext(ctx context.Context, projectID int, sharedWithGroupID int, accessLevel AccessLevel, expiresAt *ISODate) (ProjectGroupLink, error)
+	UnshareProject(projectID int, sharedWithGroupID int) error
+	UnshareProjectWithContext(ctx context.Context, projectID int, sharedWithGroupID int) error
+	GetForcePushSetting(projectID int, protectedBranch string) (bool, error)
+	GetForcePushSettingWithContext(ctx context.Context, projectID int, protectedBranch string) (bool, error)
+	ProtectBranch(projectID int, protectedBranch string) (bool, error)
+	ProtectBranchWithContext(ctx context.Context, projectID int, protectedBranch string) (bool, error)
+	DeleteProtectedBranch(projectID int, protectedBranch string) (bool, error)
+	DeleteProtectedBranchWithContext(ctx context.Context, projectID int, protectedBranch string) (bool, error)
+	GetProjectMirrors(projectID int) (ProjectMirrors, error)
+	GetProjectMirrorsWithContext(ctx context.Context, projectID int) (ProjectMirrors, error)
+	CreateProjectMirror(projectID int, mirrorURL string) (ProjectMirror, error)
+	CreateProjectMirrorWithContext(ctx context.Context, projectID int, mirrorURL string) (ProjectMirror, error)
+	UpdateProjectMirror(projectID int, mirrorID int) (ProjectMirror, error)
+	UpdateProjectMirrorWithContext(ctx context.Context, projectID int, mirrorID int) (ProjectMirror, error)
+}
+
+// GroupsService - groups, their subgroups, projects and shares
+type GroupsService interface {
+	GetGroup(groupID int) (Group, error)
+	GetGroupWithContext(ctx context.Context, groupID int) (Group, error)
+	GetGroupID(groupPath string) (int, error)
+	GetGroupIDWithContext(ctx context.Context, groupPath string) (int, error)
+	GetGroups(search string) (GroupList, error)
+	GetGroupsWithContext(ctx context.Context, search string) (GroupList, error)
+	ListGroups(opts *ListGroupsOptions) (GroupList, error)
+	ListGroupsWithContext(ctx context.Context, opts *ListGroupsOptions) (GroupList, error)
+	GetSubGroups(groupID int) (GroupList, error)
+	GetSubGroupsWithContext(ctx context.Context, groupID int) (GroupList, error)
+	ListSubGroups(groupID int, opts *ListGroupsOptions) (GroupList, error)
+	ListSubGroupsWithContext(ctx context.Context, groupID int, opts *ListGroupsOptions) (GroupList, error)
+	GetDescendantGroups(groupID int) (GroupList, error)
+	GetDescendantGroupsWithContext(ctx context.Context, groupID int) (GroupList, error)
+	ListDescendantGroups(groupID int, opts *ListGroupsOptions) (GroupList, error)
+	ListDescendantGroupsWithContext(ctx context.Context, groupID int, opts *ListGroupsOptions) (GroupList, error)
+	GetGroupProjects(groupID int) (ProjectList, error)
+	GetGroupProjectsWithContext(ctx context.Context, groupID int) (ProjectList, error)
+	ListGroupProjects(groupID int, opts *ListGroupProjectsOptions) (ProjectList, error)
+	ListGroupProjectsWithContext(ctx context.Context, groupID int, opts *ListGroupProjectsOptions) (ProjectList, error)
+	CreateGroup(opts CreateGroupOptions) (Group, error)
+	CreateGroupWithContext(ctx context.Context, opts CreateGroupOptions) (Group, error)
+	UpdateGroup(groupID int, opts UpdateGroupOptions) (Group, error)
+	UpdateGroupWithContext(ctx context.Context, groupID int, opts UpdateGroupOptions) (Group, error)
+	DeleteGroup(groupID int, opts *DeleteGroupOptions) (GroupDeletion, error)
+	DeleteGroupWithContext(ctx context.Context, groupID int, opts *DeleteGroupOptions) (GroupDeletion, error)
+	RestoreGroup(groupID int) (Group, error)
+	RestoreGroupWithContext(ctx context.Context, groupID int) (Group, error)
+	TransferGroup(groupID int, parentID int) (Group, error)
+	TransferGroupWithContext(ctx context.Context, groupID int, parentID int) (Group, error)
+	ShareGroup(groupID int, sharedWithGroupID int, accessLevel AccessLevel, expiresAt *ISODate) (Group, error)
+	ShareGroupWithContext(ctx context.Context, groupID int, sharedWithGroupID int, accessLevel AccessLevel, expiresAt *ISODate) (Group, error)
+	UnshareGroup(groupID int, sharedWithGroupID int) error
+	UnshareGroupWithContext(ctx context.Context, groupID int, sharedWithGroupID int) error
+}
+
+// MembersService - members of groups and projects
+type MembersService interface {
+	GetGroupMembers(group int) (string, error)
+	GetGroupMembersWithContext(ctx context.Context, group int) (string, error)
+	ListGroupMembers(groupID int, opts *ListMembersOptions) (Members, error)
+	ListGroupMembersWithContext(ctx context.Context, groupID int, opts *ListMembersOptions) (Members, error)
+	ListAllGroupMembers(groupID int, opts *ListMembersOptions) (Members, error)
+	ListAllGroupMembersWithContext(ctx context.Context, groupID int, opts *ListMembersOptions) (Members, error)
+	AddGroupMember(groupID, userID, accessLevel int) (string, error)
+	AddGroupMemberWithContext(ctx context.Context, groupID, userID, accessLevel int) (string, error)
+	AddGroupMemberOpts(groupID, userID int, opts MemberOptions) (Member, error)
+	AddGroupMemberOptsWithContext(ctx context.Context, groupID, userID int, opts MemberOptions) (Member, error)
+	UpdateGroupMember(groupID, userID int, opts MemberOptions) (Member, error)
+	UpdateGroupMemberWithContext(ctx context.Context, groupID, userID int, opts MemberOptions) (Member, error)
+	RemoveGroupMember(groupID, userID int) error
+	RemoveGroupMemberWithContext(ctx context.Context, groupID, userID int) error
+	GetProjectMembers(project int) (string, error)
+	GetProjectMembersWithContext(ctx context.Context, project int) (string, error)
+	ListProjectMembers(projectID int, opts *ListMembersOptions) (Members, error)
+	ListProjectMembersWithContext(ctx context.Context, projectID int, opts *ListMembersOptions) (Members, error)
+	ListAllProjectMembers(projectID int, opts *ListMembersOptions) (Members, error)
+	ListAllProjectMembersWithContext(ctx context.Context, projectID int, opts *ListMembersOptions) (Members, error)
+	AddProjectMember(projectID, userID, accessLevel int) (string, error)
+	AddProjectMemberWithContext(ctx context.Context, projectID, userID, accessLevel int) (string, error)
+	AddProjectMemberOpts(projectID, userID int, opts MemberOptions) (Member, error)
+	AddProjectMemberOptsWithContext(ctx context.Context, projectID, userID int, opts MemberOptions) (Member, error)
+	UpdateProjectMember(projectID, userID int, opts MemberOptions) (Member, error)
+	UpdateProjectMemberWithContext(ctx context.Context, projectID, userID int, opts MemberOptions) (Member, error)
+	RemoveProjectMember(projectID, userID int) error
+	RemoveProjectMemberWithContext(ctx context.Context, projectID, userID int) error
+	GetProjectAccess(projectID int) (ProjectAccess, error)
+	GetProjectAccessWithContext(ctx context.Context, projectID int) (ProjectAccess, error)
+}
+
+// PipelinesService - CI/CD pipelines
+type PipelinesService interface {
+	GetPipelines(projectID int, user string, limit int) (Pipelines, error)
+	GetPipelinesWithContext(ctx context.Context, projectID int, user string, limit int) (Pipelines, error)
+	ListPipelines(projectID int, opts *ListPipelinesOptions) (Pipelines, error)
+	ListPipelinesWithContext(ctx context.Context, projectID int, opts *ListPipelinesOptions) (Pipelines, error)
+	GetPipeline(projectID int, pipelineID int) (Pipeline, error)
+	GetPipelineWithContext(ctx context.Context, projectID int, pipelineID int) (Pipeline, error)
+}
+
+// JobsService - CI/CD jobs
+type JobsService interface {
+	ListPipelineJobs(projectID int, pipelineID int, opts *ListJobsOptions) (Jobs, error)
+	ListPipelineJobsWithContext(ctx context.Context, projectID int, pipelineID int, opts *ListJobsOptions) (Jobs, error)
+	GetJob(projectID int, jobID int) (Job, error)
+	GetJobWithContext(ctx context.Context, projectID int, jobID int) (Job, error)
+}
+
+// VariablesService - CI/CD variables of groups and projects
+type VariablesService interface {
+	GetVariableFrom(id int, resource string, variable string) (string, error)
+	GetVariableFromWithContext(ctx context.Context, id int, resource string, variable string) (string, error)
+	GetCicdVariables(projectdID int) (Variables, error)
+	GetCicdVariablesWithContext(ctx context.Context, projectdID int) (Variables, error)
+	GetCicdVariablesFromGroup(groupID int, includeProjects bool) (Variables, error)
+	GetCicdVariablesFromGroupWithContext(ctx context.Context, groupID int, includeProjects bool) (Variables, error)
+	UpdateVariableFrom(id int, resource string, variable string, value string) (string, error)
+	UpdateVariableFromWithContext(ctx context.Context, id int, resource string, variable string, value string) (string, error)
+}
+
+// MergeRequestsService - merge requests
+type MergeRequestsService interface {
+	CreateMergeRequest(projectID int, title string, sourceBranch string, targetBranch string, description string, squashOnMerge bool, removeSourceBranch bool) (string, error)
+	CreateMergeRequestWithContext(ctx context.Context, projectID int, title string, sourceBranch string, targetBranch string, description string, squashOnMerge bool, removeSourceBranch bool) (string, error)
+}
+
+// RepositoryFilesService - files in project repositories
+type RepositoryFilesService interface {
+	GetRepositoryFile(projectSlug string, fileSlug string, ref string) ([]byte, error)
+	GetRepositoryFileWithContext(ctx context.Context, projectSlug string, fileSlug string, ref string) ([]byte, error)
+}
+
+// UsersService - users, their keys and memberships
+type UsersService interface {
+	GetUsers(search string) (string, error)
+	GetUsersWithContext(ctx context.Context, search string) (string, error)
+	ListUsers(opts *ListUsersOptions) (UserList, error)
+	ListUsersWithContext(ctx context.Context, opts *ListUsersOptions) (UserList, error)
+	GetCurrentUser() (User, error)
+	GetCurrentUserWithContext(ctx context.Context) (User, error)
+	GetUser(userID int) (User, error)
+	GetUserWithContext(ctx context.Context, userID int) (User, error)
+	GetUserByUsername(username string) (User, error)
+	GetUserByUsernameWithContext(ctx context.Context, username string) (User, error)
+	ListUserSSHKeys(userID int, opts *PaginationOptions) (SSHKeys, error)
+	ListUserSSHKeysWithContext(ctx context.Context, userID int, opts *PaginationOptions) (SSHKeys, error)
+	ListUserGPGKeys(userID int, opts *PaginationOptions) (GPGKeys, error)
+	ListUserGPGKeysWithContext(ctx context.Context, userID int, opts *PaginationOptions) (GPGKeys, error)
+	ListUserMemberships(userID int, opts *ListUserMembershipsOptions) (UserMemberships, error)
+	ListUserMembershipsWithContext(ctx context.Context, userID int, opts *ListUserMembershipsOptions) (UserMemberships, error)
+	BlockUser(userID int) error
+	BlockUserWithContext(ctx context.Context, userID int) error
+	UnblockUser(userID int) error
+	UnblockUserWithContext(ctx context.Context, userID int) error
+	DeactivateUser(userID int) error
+	DeactivateUserWithContext(ctx context.Context, userID int) error
+	ActivateUser(userID int) error
+	ActivateUserWithContext(ctx context.Context, userID int) error
+}
+
+// Projects - the projects API
+func (r *gitlabClient) Projects() ProjectsService { return r }
+
+// Groups - the groups API
+func (r *gitlabClient) Groups() GroupsService { return r }
+
+// Members - the group and project members API
+func (r *gitlabClient) Members() MembersService { return r }
+
+// Pipelines - the pipelines API
+func (r *gitlabClient) Pipelines() PipelinesService { return r }
+
+// Jobs - the jobs API
+func (r *gitlabClient) Jobs() JobsService { return r }
+
+// Variables - the CI/CD variables API
+func (r *gitlabClient) Variables() VariablesService { return r }
+
+// MergeRequests - the merge requests API
+func (r *gitlabClient) MergeRequests() MergeRequestsService { return r }
+
+// RepositoryFiles - the repository files API
+func (r *gitlabClient) RepositoryFiles() RepositoryFilesService { return r }
+
+// Users - the users API
+func (r *gitlabClient) Users() UsersService { return r }